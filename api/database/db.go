@@ -7,33 +7,81 @@ import (
 	"time"
 
 	_ "github.com/go-sql-driver/mysql"
+	_ "github.com/lib/pq"
+	_ "modernc.org/sqlite"
 )
 
-func InitDB() (*sql.DB, error) {
-	dbUser := getEnv("DB_USER", "root")
-	dbPassword := getEnv("DB_PASSWORD", "password")
-	dbHost := getEnv("DB_HOST", "db")
-	dbPort := getEnv("DB_PORT", "3306")
-	dbName := getEnv("DB_NAME", "todo_app")
+// InitDB opens a *sql.DB for the backend selected by DB_DRIVER (mysql,
+// postgres, or sqlite; defaults to mysql), runs pending migrations, and
+// returns the connection alongside the Dialect the repository layer should
+// use to build driver-appropriate SQL.
+func InitDB() (*sql.DB, Dialect, error) {
+	driver := Driver(getEnv("DB_DRIVER", string(DriverMySQL)))
 
-	dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?parseTime=true", 
-		dbUser, dbPassword, dbHost, dbPort, dbName)
+	dsn, sqlDriverName, err := dsnFor(driver)
+	if err != nil {
+		return nil, Dialect{}, err
+	}
 
-	db, err := sql.Open("mysql", dsn)
+	db, err := sql.Open(sqlDriverName, dsn)
 	if err != nil {
-		return nil, err
+		return nil, Dialect{}, err
 	}
 
-	db.SetMaxOpenConns(25)
-	db.SetMaxIdleConns(5)
-	db.SetConnMaxLifetime(5 * time.Minute)
+	if driver != DriverSQLite {
+		db.SetMaxOpenConns(25)
+		db.SetMaxIdleConns(5)
+		db.SetConnMaxLifetime(5 * time.Minute)
+	}
 
-	err = db.Ping()
-	if err != nil {
-		return nil, err
+	if err := db.Ping(); err != nil {
+		return nil, Dialect{}, err
 	}
 
-	return db, nil
+	if err := RunMigrations(db, driver); err != nil {
+		return nil, Dialect{}, fmt.Errorf("running migrations: %w", err)
+	}
+
+	return db, Dialect{Driver: driver}, nil
+}
+
+// dsnFor builds the connection string and the database/sql driver name to
+// register InitDB's sql.Open call with, for the given Driver.
+func dsnFor(driver Driver) (dsn string, sqlDriverName string, err error) {
+	switch driver {
+	case DriverMySQL:
+		dbUser := getEnv("DB_USER", "root")
+		dbPassword := getEnv("DB_PASSWORD", "password")
+		dbHost := getEnv("DB_HOST", "db")
+		dbPort := getEnv("DB_PORT", "3306")
+		dbName := getEnv("DB_NAME", "todo_app")
+
+		// multiStatements lets golang-migrate run each migration file, which
+		// holds several statements, in a single Exec.
+		dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?parseTime=true&multiStatements=true",
+			dbUser, dbPassword, dbHost, dbPort, dbName)
+		return dsn, "mysql", nil
+
+	case DriverPostgres:
+		dbUser := getEnv("DB_USER", "postgres")
+		dbPassword := getEnv("DB_PASSWORD", "password")
+		dbHost := getEnv("DB_HOST", "db")
+		dbPort := getEnv("DB_PORT", "5432")
+		dbName := getEnv("DB_NAME", "todo_app")
+
+		dsn := fmt.Sprintf("postgres://%s:%s@%s:%s/%s?sslmode=disable",
+			dbUser, dbPassword, dbHost, dbPort, dbName)
+		return dsn, "postgres", nil
+
+	case DriverSQLite:
+		path := getEnv("DB_PATH", "todo_app.db")
+		// modernc.org/sqlite defaults foreign-key enforcement to off, which
+		// would silently disable every ON DELETE CASCADE in the schema.
+		return path + "?_pragma=foreign_keys(1)", "sqlite", nil
+
+	default:
+		return "", "", fmt.Errorf("unsupported DB_DRIVER: %q", driver)
+	}
 }
 
 func getEnv(key, defaultValue string) string {
@@ -0,0 +1,19 @@
+package database
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDsnFor_SQLiteEnablesForeignKeys(t *testing.T) {
+	dsn, sqlDriverName, err := dsnFor(DriverSQLite)
+	if err != nil {
+		t.Fatalf("dsnFor: %v", err)
+	}
+	if sqlDriverName != "sqlite" {
+		t.Fatalf("expected sql driver name %q, got %q", "sqlite", sqlDriverName)
+	}
+	if !strings.Contains(dsn, "_pragma=foreign_keys(1)") {
+		t.Fatalf("expected sqlite DSN to enable foreign_keys, got %q", dsn)
+	}
+}
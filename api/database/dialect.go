@@ -0,0 +1,53 @@
+package database
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Driver identifies which SQL backend a *sql.DB is talking to.
+type Driver string
+
+const (
+	DriverMySQL    Driver = "mysql"
+	DriverPostgres Driver = "postgres"
+	DriverSQLite   Driver = "sqlite"
+)
+
+// Dialect carries the driver-specific SQL differences the repository layer
+// needs to account for (placeholder syntax today; more as backends diverge).
+type Dialect struct {
+	Driver Driver
+}
+
+// Placeholder returns the bind-parameter syntax for the n-th (1-indexed)
+// argument in a query, e.g. "?" for MySQL/SQLite or "$1" for Postgres.
+func (d Dialect) Placeholder(n int) string {
+	if d.Driver == DriverPostgres {
+		return fmt.Sprintf("$%d", n)
+	}
+	return "?"
+}
+
+// Rebind rewrites a query written with MySQL/SQLite-style "?" placeholders
+// into this dialect's syntax, numbering them in order. Call sites that build
+// their SQL as a static string (rather than assembling it clause-by-clause
+// with Placeholder, as the repository package does) can write it once using
+// "?" and rebind it for whichever driver is configured.
+func (d Dialect) Rebind(query string) string {
+	if d.Driver != DriverPostgres {
+		return query
+	}
+
+	var b strings.Builder
+	n := 0
+	for _, r := range query {
+		if r == '?' {
+			n++
+			b.WriteString(d.Placeholder(n))
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
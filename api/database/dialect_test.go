@@ -0,0 +1,48 @@
+package database
+
+import "testing"
+
+func TestDialect_Placeholder(t *testing.T) {
+	tests := []struct {
+		name   string
+		driver Driver
+		n      int
+		want   string
+	}{
+		{name: "mysql", driver: DriverMySQL, n: 1, want: "?"},
+		{name: "sqlite", driver: DriverSQLite, n: 3, want: "?"},
+		{name: "postgres first", driver: DriverPostgres, n: 1, want: "$1"},
+		{name: "postgres third", driver: DriverPostgres, n: 3, want: "$3"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := Dialect{Driver: tt.driver}
+			if got := d.Placeholder(tt.n); got != tt.want {
+				t.Errorf("Placeholder(%d) = %q, want %q", tt.n, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDialect_Rebind(t *testing.T) {
+	tests := []struct {
+		name   string
+		driver Driver
+		query  string
+		want   string
+	}{
+		{name: "mysql unchanged", driver: DriverMySQL, query: "SELECT 1 FROM t WHERE a = ? AND b = ?", want: "SELECT 1 FROM t WHERE a = ? AND b = ?"},
+		{name: "sqlite unchanged", driver: DriverSQLite, query: "SELECT 1 FROM t WHERE a = ?", want: "SELECT 1 FROM t WHERE a = ?"},
+		{name: "postgres numbers in order", driver: DriverPostgres, query: "SELECT 1 FROM t WHERE a = ? AND b = ?", want: "SELECT 1 FROM t WHERE a = $1 AND b = $2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := Dialect{Driver: tt.driver}
+			if got := d.Rebind(tt.query); got != tt.want {
+				t.Errorf("Rebind(%q) = %q, want %q", tt.query, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package database
+
+import (
+	"database/sql"
+	"embed"
+	"errors"
+	"fmt"
+
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/database"
+	"github.com/golang-migrate/migrate/v4/database/mysql"
+	"github.com/golang-migrate/migrate/v4/database/postgres"
+	"github.com/golang-migrate/migrate/v4/database/sqlite"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+)
+
+//go:embed migrations/mysql/*.sql migrations/postgres/*.sql migrations/sqlite/*.sql
+var migrationFiles embed.FS
+
+// RunMigrations applies any pending schema migrations for driver's dialect,
+// embedded under migrations/<driver>, to db.
+func RunMigrations(db *sql.DB, driver Driver) error {
+	sourceDir := fmt.Sprintf("migrations/%s", driver)
+	source, err := iofs.New(migrationFiles, sourceDir)
+	if err != nil {
+		return fmt.Errorf("loading %s migrations: %w", driver, err)
+	}
+
+	dbDriver, err := migrationDBDriver(db, driver)
+	if err != nil {
+		return err
+	}
+
+	m, err := migrate.NewWithInstance("iofs", source, string(driver), dbDriver)
+	if err != nil {
+		return fmt.Errorf("initializing migrate instance: %w", err)
+	}
+
+	if err := m.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return err
+	}
+	return nil
+}
+
+// migrationDBDriver wraps db in the golang-migrate database.Driver
+// implementation matching driver.
+func migrationDBDriver(db *sql.DB, driver Driver) (database.Driver, error) {
+	switch driver {
+	case DriverMySQL:
+		return mysql.WithInstance(db, &mysql.Config{})
+	case DriverPostgres:
+		return postgres.WithInstance(db, &postgres.Config{})
+	case DriverSQLite:
+		return sqlite.WithInstance(db, &sqlite.Config{})
+	default:
+		return nil, fmt.Errorf("unsupported DB_DRIVER: %q", driver)
+	}
+}
@@ -0,0 +1,181 @@
+package handlers
+
+import (
+	"database/sql"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/k-satosi/todo-app-webui/api/auth"
+	"github.com/k-satosi/todo-app-webui/api/database"
+	"github.com/k-satosi/todo-app-webui/api/models"
+)
+
+type AuthHandler struct {
+	DB      *sql.DB
+	Dialect database.Dialect
+}
+
+func NewAuthHandler(db *sql.DB, dialect database.Dialect) *AuthHandler {
+	return &AuthHandler{DB: db, Dialect: dialect}
+}
+
+func (h *AuthHandler) Register(c *gin.Context) {
+	var req models.RegisterRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	passwordHash, err := auth.HashPassword(req.Password)
+	if err != nil {
+		log.Printf("Error hashing password: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to register user"})
+		return
+	}
+
+	now := time.Now()
+	id := uuid.New().String()
+
+	_, err = h.DB.Exec(h.Dialect.Rebind(`
+		INSERT INTO users (id, email, password_hash, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?)
+	`), id, req.Email, passwordHash, now, now)
+
+	if err != nil {
+		log.Printf("Error creating user: %v", err)
+		c.JSON(http.StatusConflict, gin.H{"error": "Email already registered"})
+		return
+	}
+
+	tokens, err := h.issueTokenPair(id)
+	if err != nil {
+		log.Printf("Error issuing tokens: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to register user"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, tokens)
+}
+
+func (h *AuthHandler) Login(c *gin.Context) {
+	var req models.LoginRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var userID, passwordHash string
+	err := h.DB.QueryRow(h.Dialect.Rebind(`
+		SELECT id, password_hash FROM users WHERE email = ?
+	`), req.Email).Scan(&userID, &passwordHash)
+
+	if err == sql.ErrNoRows || (err == nil && !auth.CheckPassword(passwordHash, req.Password)) {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid email or password"})
+		return
+	} else if err != nil {
+		log.Printf("Error querying user: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to log in"})
+		return
+	}
+
+	tokens, err := h.issueTokenPair(userID)
+	if err != nil {
+		log.Printf("Error issuing tokens: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to log in"})
+		return
+	}
+
+	c.JSON(http.StatusOK, tokens)
+}
+
+func (h *AuthHandler) Refresh(c *gin.Context) {
+	var req models.RefreshRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	tokenHash := auth.HashRefreshToken(req.RefreshToken)
+
+	var id, userID string
+	var revoked bool
+	var expiresAt time.Time
+	err := h.DB.QueryRow(h.Dialect.Rebind(`
+		SELECT id, user_id, revoked, expires_at FROM refresh_tokens WHERE token_hash = ?
+	`), tokenHash).Scan(&id, &userID, &revoked, &expiresAt)
+
+	if err == sql.ErrNoRows || (err == nil && (revoked || time.Now().After(expiresAt))) {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired refresh token"})
+		return
+	} else if err != nil {
+		log.Printf("Error querying refresh token: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to refresh token"})
+		return
+	}
+
+	// Rotate: revoke the presented refresh token before issuing a new pair.
+	if _, err := h.DB.Exec(h.Dialect.Rebind(`UPDATE refresh_tokens SET revoked = TRUE WHERE id = ?`), id); err != nil {
+		log.Printf("Error revoking refresh token: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to refresh token"})
+		return
+	}
+
+	tokens, err := h.issueTokenPair(userID)
+	if err != nil {
+		log.Printf("Error issuing tokens: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to refresh token"})
+		return
+	}
+
+	c.JSON(http.StatusOK, tokens)
+}
+
+func (h *AuthHandler) Logout(c *gin.Context) {
+	var req models.RefreshRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	tokenHash := auth.HashRefreshToken(req.RefreshToken)
+
+	if _, err := h.DB.Exec(h.Dialect.Rebind(`UPDATE refresh_tokens SET revoked = TRUE WHERE token_hash = ?`), tokenHash); err != nil {
+		log.Printf("Error revoking refresh token: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to log out"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Logged out successfully"})
+}
+
+// issueTokenPair mints a fresh access/refresh token pair for userID and persists
+// the refresh token's hash so it can be rotated or revoked later.
+func (h *AuthHandler) issueTokenPair(userID string) (models.AuthResponse, error) {
+	accessToken, err := auth.GenerateAccessToken(userID)
+	if err != nil {
+		return models.AuthResponse{}, err
+	}
+
+	refreshToken, err := auth.GenerateRefreshToken()
+	if err != nil {
+		return models.AuthResponse{}, err
+	}
+
+	now := time.Now()
+	_, err = h.DB.Exec(h.Dialect.Rebind(`
+		INSERT INTO refresh_tokens (id, user_id, token_hash, revoked, expires_at, created_at)
+		VALUES (?, ?, ?, FALSE, ?, ?)
+	`), uuid.New().String(), userID, auth.HashRefreshToken(refreshToken), now.Add(auth.RefreshTokenTTL), now)
+	if err != nil {
+		return models.AuthResponse{}, err
+	}
+
+	return models.AuthResponse{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		ExpiresIn:    int(auth.AccessTokenTTL.Seconds()),
+	}, nil
+}
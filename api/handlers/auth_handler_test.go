@@ -0,0 +1,207 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/k-satosi/todo-app-webui/api/auth"
+	"github.com/k-satosi/todo-app-webui/api/database"
+	"github.com/k-satosi/todo-app-webui/api/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newAuthHandlerWithMock(t *testing.T) (*AuthHandler, sqlmock.Sqlmock) {
+	t.Helper()
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	return NewAuthHandler(db, database.Dialect{Driver: database.DriverMySQL}), mock
+}
+
+func TestAuthHandler_Register(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	handler, mock := newAuthHandlerWithMock(t)
+
+	mock.ExpectExec("INSERT INTO users").
+		WithArgs(sqlmock.AnyArg(), "new@example.com", sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectExec("INSERT INTO refresh_tokens").
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	r := gin.Default()
+	r.POST("/auth/register", handler.Register)
+
+	body, _ := json.Marshal(models.RegisterRequest{Email: "new@example.com", Password: "s3cr3tpass"})
+	req, _ := http.NewRequest("POST", "/auth/register", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	var tokens models.AuthResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &tokens))
+	assert.NotEmpty(t, tokens.AccessToken)
+	assert.NotEmpty(t, tokens.RefreshToken)
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAuthHandler_Register_DuplicateEmail(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	handler, mock := newAuthHandlerWithMock(t)
+
+	mock.ExpectExec("INSERT INTO users").
+		WillReturnError(assert.AnError)
+
+	r := gin.Default()
+	r.POST("/auth/register", handler.Register)
+
+	body, _ := json.Marshal(models.RegisterRequest{Email: "taken@example.com", Password: "s3cr3tpass"})
+	req, _ := http.NewRequest("POST", "/auth/register", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAuthHandler_Login(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	handler, mock := newAuthHandlerWithMock(t)
+
+	passwordHash, err := auth.HashPassword("correct-password")
+	require.NoError(t, err)
+
+	mock.ExpectQuery("SELECT id, password_hash FROM users WHERE email = ?").
+		WithArgs("user@example.com").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "password_hash"}).AddRow("user-1", passwordHash))
+	mock.ExpectExec("INSERT INTO refresh_tokens").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	r := gin.Default()
+	r.POST("/auth/login", handler.Login)
+
+	body, _ := json.Marshal(models.LoginRequest{Email: "user@example.com", Password: "correct-password"})
+	req, _ := http.NewRequest("POST", "/auth/login", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAuthHandler_Login_WrongPassword(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	handler, mock := newAuthHandlerWithMock(t)
+
+	passwordHash, err := auth.HashPassword("correct-password")
+	require.NoError(t, err)
+
+	mock.ExpectQuery("SELECT id, password_hash FROM users WHERE email = ?").
+		WithArgs("user@example.com").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "password_hash"}).AddRow("user-1", passwordHash))
+
+	r := gin.Default()
+	r.POST("/auth/login", handler.Login)
+
+	body, _ := json.Marshal(models.LoginRequest{Email: "user@example.com", Password: "wrong-password"})
+	req, _ := http.NewRequest("POST", "/auth/login", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAuthHandler_Refresh_RotatesToken(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	handler, mock := newAuthHandlerWithMock(t)
+
+	tokenHash := auth.HashRefreshToken("presented-refresh-token")
+
+	mock.ExpectQuery("SELECT id, user_id, revoked, expires_at FROM refresh_tokens WHERE token_hash = ?").
+		WithArgs(tokenHash).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "revoked", "expires_at"}).
+			AddRow("token-1", "user-1", false, time.Now().Add(time.Hour)))
+	mock.ExpectExec("UPDATE refresh_tokens SET revoked = TRUE WHERE id = ?").
+		WithArgs("token-1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO refresh_tokens").
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	r := gin.Default()
+	r.POST("/auth/refresh", handler.Refresh)
+
+	body, _ := json.Marshal(models.RefreshRequest{RefreshToken: "presented-refresh-token"})
+	req, _ := http.NewRequest("POST", "/auth/refresh", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var tokens models.AuthResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &tokens))
+	assert.NotEqual(t, "presented-refresh-token", tokens.RefreshToken)
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAuthHandler_Refresh_RevokedTokenRejected(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	handler, mock := newAuthHandlerWithMock(t)
+
+	tokenHash := auth.HashRefreshToken("revoked-token")
+
+	mock.ExpectQuery("SELECT id, user_id, revoked, expires_at FROM refresh_tokens WHERE token_hash = ?").
+		WithArgs(tokenHash).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "revoked", "expires_at"}).
+			AddRow("token-1", "user-1", true, time.Now().Add(time.Hour)))
+
+	r := gin.Default()
+	r.POST("/auth/refresh", handler.Refresh)
+
+	body, _ := json.Marshal(models.RefreshRequest{RefreshToken: "revoked-token"})
+	req, _ := http.NewRequest("POST", "/auth/refresh", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAuthHandler_Logout(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	handler, mock := newAuthHandlerWithMock(t)
+
+	tokenHash := auth.HashRefreshToken("a-refresh-token")
+
+	mock.ExpectExec("UPDATE refresh_tokens SET revoked = TRUE WHERE token_hash = ?").
+		WithArgs(tokenHash).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	r := gin.Default()
+	r.POST("/auth/logout", handler.Logout)
+
+	body, _ := json.Marshal(models.RefreshRequest{RefreshToken: "a-refresh-token"})
+	req, _ := http.NewRequest("POST", "/auth/logout", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
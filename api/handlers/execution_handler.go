@@ -0,0 +1,84 @@
+package handlers
+
+import (
+	"database/sql"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/k-satosi/todo-app-webui/api/database"
+	"github.com/k-satosi/todo-app-webui/api/middleware"
+	"github.com/k-satosi/todo-app-webui/api/models"
+	"github.com/k-satosi/todo-app-webui/api/scheduler"
+)
+
+type ExecutionHandler struct {
+	DB        *sql.DB
+	Dialect   database.Dialect
+	Scheduler *scheduler.Manager
+}
+
+func NewExecutionHandler(db *sql.DB, dialect database.Dialect, mgr *scheduler.Manager) *ExecutionHandler {
+	return &ExecutionHandler{DB: db, Dialect: dialect, Scheduler: mgr}
+}
+
+// ListExecutions returns every TaskExecution scheduled for the given task,
+// most recent first. The user_id join scopes results to the caller's own tasks.
+func (h *ExecutionHandler) ListExecutions(c *gin.Context) {
+	taskID := c.Param("id")
+	userID := c.GetString(middleware.UserIDKey)
+
+	rows, err := h.DB.Query(h.Dialect.Rebind(`
+		SELECT e.id, e.task_id, e.scheduled_for, e.status, e.completed_at
+		FROM task_executions e
+		JOIN tasks t ON t.id = e.task_id
+		WHERE e.task_id = ? AND t.user_id = ?
+		ORDER BY e.scheduled_for DESC
+	`), taskID, userID)
+	if err != nil {
+		log.Printf("Error querying task executions: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve executions"})
+		return
+	}
+	defer rows.Close()
+
+	var executions []models.TaskExecution
+	for rows.Next() {
+		var e models.TaskExecution
+		if err := rows.Scan(&e.ID, &e.TaskID, &e.ScheduledFor, &e.Status, &e.CompletedAt); err != nil {
+			log.Printf("Error scanning task execution row: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to process executions"})
+			return
+		}
+		executions = append(executions, e)
+	}
+
+	c.JSON(http.StatusOK, executions)
+}
+
+// CompleteExecution marks an execution completed.
+func (h *ExecutionHandler) CompleteExecution(c *gin.Context) {
+	h.transition(c, models.ExecutionCompleted)
+}
+
+// SkipExecution marks an execution skipped.
+func (h *ExecutionHandler) SkipExecution(c *gin.Context) {
+	h.transition(c, models.ExecutionSkipped)
+}
+
+func (h *ExecutionHandler) transition(c *gin.Context, status models.ExecutionStatus) {
+	executionID := c.Param("executionId")
+	userID := c.GetString(middleware.UserIDKey)
+
+	execution, err := h.Scheduler.UpdateExecutionStatus(c.Request.Context(), executionID, userID, status)
+	if err == sql.ErrNoRows {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Execution not found"})
+		return
+	} else if err != nil {
+		log.Printf("Error updating execution status: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update execution"})
+		return
+	}
+
+	c.JSON(http.StatusOK, execution)
+}
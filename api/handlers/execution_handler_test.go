@@ -0,0 +1,119 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/k-satosi/todo-app-webui/api/database"
+	"github.com/k-satosi/todo-app-webui/api/middleware"
+	"github.com/k-satosi/todo-app-webui/api/models"
+	"github.com/k-satosi/todo-app-webui/api/scheduler"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecutionHandler_ListExecutions(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectQuery("SELECT e.id, e.task_id, e.scheduled_for, e.status, e.completed_at").
+		WithArgs("task-1", testUserID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "task_id", "scheduled_for", "status", "completed_at"}).
+			AddRow("exec-1", "task-1", now, models.ExecutionPending, nil))
+
+	handler := &ExecutionHandler{DB: db, Dialect: database.Dialect{Driver: database.DriverMySQL}}
+
+	r := gin.Default()
+	withMockAuth(r)
+	r.GET("/tasks/:id/executions", handler.ListExecutions)
+
+	req, _ := http.NewRequest("GET", "/tasks/task-1/executions", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var executions []models.TaskExecution
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &executions))
+	require.Len(t, executions, 1)
+	assert.Equal(t, "exec-1", executions[0].ID)
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestExecutionHandler_CompleteExecution(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	dialect := database.Dialect{Driver: database.DriverMySQL}
+
+	mock.ExpectExec("UPDATE task_executions").
+		WithArgs(models.ExecutionCompleted, sqlmock.AnyArg(), "exec-1", testUserID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery("SELECT e.id, e.task_id, e.scheduled_for, e.status, e.completed_at").
+		WithArgs("exec-1", testUserID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "task_id", "scheduled_for", "status", "completed_at"}).
+			AddRow("exec-1", "task-1", time.Now(), models.ExecutionCompleted, time.Now()))
+
+	mgr := scheduler.NewManager(db, dialect, nil)
+	handler := &ExecutionHandler{DB: db, Dialect: dialect, Scheduler: mgr}
+
+	r := gin.Default()
+	withMockAuth(r)
+	r.POST("/tasks/:id/executions/:executionId/complete", handler.CompleteExecution)
+
+	req, _ := http.NewRequest("POST", "/tasks/task-1/executions/exec-1/complete", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var execution models.TaskExecution
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &execution))
+	assert.Equal(t, models.ExecutionCompleted, execution.Status)
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestExecutionHandler_CompleteExecution_NotFound(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	dialect := database.Dialect{Driver: database.DriverMySQL}
+
+	mock.ExpectExec("UPDATE task_executions").
+		WithArgs(models.ExecutionCompleted, sqlmock.AnyArg(), "exec-1", "someone-else").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	mgr := scheduler.NewManager(db, dialect, nil)
+	handler := &ExecutionHandler{DB: db, Dialect: dialect, Scheduler: mgr}
+
+	r := gin.Default()
+	r.Use(func(c *gin.Context) {
+		c.Set(middleware.UserIDKey, "someone-else")
+		c.Next()
+	})
+	r.POST("/tasks/:id/executions/:executionId/complete", handler.CompleteExecution)
+
+	req, _ := http.NewRequest("POST", "/tasks/task-1/executions/exec-1/complete", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
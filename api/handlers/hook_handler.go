@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"database/sql"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/k-satosi/todo-app-webui/api/hooks"
+	"github.com/k-satosi/todo-app-webui/api/middleware"
+	"github.com/k-satosi/todo-app-webui/api/models"
+)
+
+type HookHandler struct {
+	Dispatcher *hooks.Dispatcher
+}
+
+func NewHookHandler(dispatcher *hooks.Dispatcher) *HookHandler {
+	return &HookHandler{Dispatcher: dispatcher}
+}
+
+// CreateHook registers a new outbound webhook for the caller.
+func (h *HookHandler) CreateHook(c *gin.Context) {
+	var req models.HookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID := c.GetString(middleware.UserIDKey)
+
+	hook, err := h.Dispatcher.RegisterHook(c.Request.Context(), userID, req)
+	if err != nil {
+		log.Printf("Error registering hook: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to register hook"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, hook)
+}
+
+// ListDeliveries returns the delivery attempt history for a hook owned by the caller.
+func (h *HookHandler) ListDeliveries(c *gin.Context) {
+	hookID := c.Param("id")
+	userID := c.GetString(middleware.UserIDKey)
+
+	deliveries, err := h.Dispatcher.ListDeliveries(c.Request.Context(), hookID, userID)
+	if err == sql.ErrNoRows {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Hook not found"})
+		return
+	} else if err != nil {
+		log.Printf("Error listing deliveries for hook %s: %v", hookID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve deliveries"})
+		return
+	}
+
+	c.JSON(http.StatusOK, deliveries)
+}
+
+// RetryDelivery re-attempts a failed or exhausted delivery immediately.
+func (h *HookHandler) RetryDelivery(c *gin.Context) {
+	hookID := c.Param("id")
+	deliveryID := c.Param("deliveryId")
+	userID := c.GetString(middleware.UserIDKey)
+
+	delivery, err := h.Dispatcher.RetryDelivery(c.Request.Context(), deliveryID, userID)
+	if err == sql.ErrNoRows {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Delivery not found"})
+		return
+	} else if err != nil {
+		log.Printf("Error retrying delivery %s for hook %s: %v", deliveryID, hookID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retry delivery"})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, delivery)
+}
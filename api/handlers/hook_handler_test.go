@@ -0,0 +1,146 @@
+package handlers
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/k-satosi/todo-app-webui/api/database"
+	"github.com/k-satosi/todo-app-webui/api/hooks"
+	"github.com/k-satosi/todo-app-webui/api/middleware"
+	"github.com/k-satosi/todo-app-webui/api/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func withMockAuthAs(r *gin.Engine, userID string) {
+	r.Use(func(c *gin.Context) {
+		c.Set(middleware.UserIDKey, userID)
+		c.Next()
+	})
+}
+
+func TestHookHandler_CreateHook(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectExec("INSERT INTO hooks").
+		WithArgs(sqlmock.AnyArg(), testUserID, "https://example.com/hook", "s3cret", "task.created", sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	handler := NewHookHandler(hooks.NewDispatcher(db, database.Dialect{Driver: database.DriverMySQL}, 1))
+
+	r := gin.Default()
+	withMockAuth(r)
+	r.POST("/hooks", handler.CreateHook)
+
+	body, _ := json.Marshal(models.HookRequest{URL: "https://example.com/hook", Secret: "s3cret", Events: []string{"task.created"}})
+	req, _ := http.NewRequest("POST", "/hooks", bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	var hook models.Hook
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &hook))
+	assert.Equal(t, "https://example.com/hook", hook.URL)
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestHookHandler_ListDeliveries(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	now := time.Now()
+	mock.ExpectQuery("SELECT id, user_id, url, secret, events, created_at, updated_at").
+		WithArgs("hook-1", testUserID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "url", "secret", "events", "created_at", "updated_at"}).
+			AddRow("hook-1", testUserID, "https://example.com/hook", "s3cret", "task.created", now, now))
+	mock.ExpectQuery("SELECT id, hook_id, event, payload, status, attempt, status_code, error, next_retry_at, created_at, updated_at").
+		WithArgs("hook-1").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "hook_id", "event", "payload", "status", "attempt", "status_code", "error", "next_retry_at", "created_at", "updated_at"}).
+			AddRow("delivery-1", "hook-1", "task.created", "{}", models.DeliverySucceeded, 1, 200, "", nil, now, now))
+
+	handler := NewHookHandler(hooks.NewDispatcher(db, database.Dialect{Driver: database.DriverMySQL}, 1))
+
+	r := gin.Default()
+	withMockAuth(r)
+	r.GET("/hooks/:id/deliveries", handler.ListDeliveries)
+
+	req, _ := http.NewRequest("GET", "/hooks/hook-1/deliveries", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var deliveries []models.Delivery
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &deliveries))
+	require.Len(t, deliveries, 1)
+	assert.Equal(t, "delivery-1", deliveries[0].ID)
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestHookHandler_ListDeliveries_OtherUsersHookNotFound(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT id, user_id, url, secret, events, created_at, updated_at").
+		WithArgs("hook-1", "someone-else").
+		WillReturnError(sql.ErrNoRows)
+
+	handler := NewHookHandler(hooks.NewDispatcher(db, database.Dialect{Driver: database.DriverMySQL}, 1))
+
+	r := gin.Default()
+	withMockAuthAs(r, "someone-else")
+	r.GET("/hooks/:id/deliveries", handler.ListDeliveries)
+
+	req, _ := http.NewRequest("GET", "/hooks/hook-1/deliveries", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestHookHandler_RetryDelivery_NotFound(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT id, hook_id, event, payload, status, attempt, status_code, error, next_retry_at, created_at, updated_at").
+		WithArgs("delivery-1").
+		WillReturnError(sql.ErrNoRows)
+
+	handler := NewHookHandler(hooks.NewDispatcher(db, database.Dialect{Driver: database.DriverMySQL}, 1))
+
+	r := gin.Default()
+	withMockAuth(r)
+	r.POST("/hooks/:id/deliveries/:deliveryId/retry", handler.RetryDelivery)
+
+	req, _ := http.NewRequest("POST", "/hooks/hook-1/deliveries/delivery-1/retry", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
@@ -1,73 +1,264 @@
 package handlers
 
 import (
-	"database/sql"
+	"errors"
+	"fmt"
 	"log"
 	"net/http"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/k-satosi/todo-app-webui/api/hooks"
+	"github.com/k-satosi/todo-app-webui/api/middleware"
 	"github.com/k-satosi/todo-app-webui/api/models"
+	"github.com/k-satosi/todo-app-webui/api/repository"
+	"github.com/k-satosi/todo-app-webui/api/scheduler"
 )
 
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+)
+
+// sortableColumns allow-lists the sort fields GetTasks accepts in its `sort`
+// query parameter, mirroring the allow-list the repository applies to SQL.
+var sortableColumns = map[string]bool{
+	"title":      true,
+	"due_date":   true,
+	"created_at": true,
+	"updated_at": true,
+}
+
+// taskListParams is the parsed and validated form of GetTasks's query parameters.
+type taskListParams struct {
+	page      int
+	pageSize  int
+	status    string
+	q         string
+	dueBefore *time.Time
+	dueAfter  *time.Time
+	sort      []string
+}
+
+func parseTaskListParams(c *gin.Context) (taskListParams, error) {
+	params := taskListParams{page: 1, pageSize: defaultPageSize}
+
+	if v := c.Query("page"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			return params, fmt.Errorf("invalid page: %q", v)
+		}
+		params.page = n
+	}
+
+	if v := c.Query("page_size"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > maxPageSize {
+			return params, fmt.Errorf("invalid page_size: %q", v)
+		}
+		params.pageSize = n
+	}
+
+	if v := c.Query("status"); v != "" {
+		switch v {
+		case "completed", "pending", "overdue":
+			params.status = v
+		default:
+			return params, fmt.Errorf("invalid status: %q", v)
+		}
+	}
+
+	params.q = c.Query("q")
+
+	if v := c.Query("due_before"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return params, fmt.Errorf("invalid due_before: %q", v)
+		}
+		params.dueBefore = &t
+	}
+
+	if v := c.Query("due_after"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return params, fmt.Errorf("invalid due_after: %q", v)
+		}
+		params.dueAfter = &t
+	}
+
+	if v := c.Query("sort"); v != "" {
+		for _, field := range strings.Split(v, ",") {
+			column := strings.TrimPrefix(field, "-")
+			if !sortableColumns[column] {
+				return params, fmt.Errorf("invalid sort field: %q", field)
+			}
+			params.sort = append(params.sort, field)
+		}
+	} else {
+		params.sort = []string{"due_date"}
+	}
+
+	return params, nil
+}
+
+// toFilter converts the parsed query parameters into the repository's filter type.
+func (p taskListParams) toFilter(userID string) repository.TaskFilter {
+	return repository.TaskFilter{
+		UserID:    userID,
+		Status:    p.status,
+		Query:     p.q,
+		DueBefore: p.dueBefore,
+		DueAfter:  p.dueAfter,
+		Sort:      p.sort,
+		Limit:     p.pageSize,
+		Offset:    (p.page - 1) * p.pageSize,
+	}
+}
+
+// isListV2Request gates the new {"items", "page", ...} response envelope
+// behind an explicit opt-in, so existing clients expecting a bare array keep working.
+func isListV2Request(c *gin.Context) bool {
+	return strings.Contains(c.GetHeader("Accept"), "version=2") || c.GetHeader("X-API-Version") == "2"
+}
+
 type TaskHandler struct {
-	DB *sql.DB
+	Tasks      repository.TaskRepository
+	Scheduler  *scheduler.Manager
+	Dispatcher *hooks.Dispatcher
 }
 
-func NewTaskHandler(db *sql.DB) *TaskHandler {
-	return &TaskHandler{DB: db}
+func NewTaskHandler(tasks repository.TaskRepository, mgr *scheduler.Manager, dispatcher *hooks.Dispatcher) *TaskHandler {
+	return &TaskHandler{Tasks: tasks, Scheduler: mgr, Dispatcher: dispatcher}
+}
+
+// publishEvent notifies registered webhooks of a task lifecycle event. It is
+// a no-op when no Dispatcher is configured, so handlers stay usable in tests
+// that don't care about hooks.
+func (h *TaskHandler) publishEvent(eventType, userID string, task models.Task) {
+	if h.Dispatcher == nil {
+		return
+	}
+	h.Dispatcher.Publish(hooks.Event{Type: eventType, UserID: userID, Task: task})
+}
+
+// scheduleIfRecurring registers the task's next occurrence with the scheduler
+// when it carries a recurrence rule, or cancels any occurrence already
+// pending for it when the rule is empty (e.g. cleared on update). Invalid
+// rules are rejected by the caller before this point, so a parse failure
+// here only logs.
+func (h *TaskHandler) scheduleIfRecurring(taskID, userID, rule string, dueDate time.Time) {
+	if h.Scheduler == nil {
+		return
+	}
+	if rule == "" {
+		h.Scheduler.Cancel(taskID)
+		return
+	}
+	parsed, err := scheduler.ParseRRule(rule)
+	if err != nil {
+		log.Printf("Error parsing recurrence rule for task %s: %v", taskID, err)
+		return
+	}
+	h.Scheduler.AddRecurringTask(taskID, userID, dueDate, parsed)
 }
 
 func (h *TaskHandler) GetTasks(c *gin.Context) {
-	rows, err := h.DB.Query(`
-		SELECT id, title, due_date, completed, created_at, updated_at 
-		FROM tasks 
-		ORDER BY due_date ASC
-	`)
+	userID := c.GetString(middleware.UserIDKey)
+
+	params, err := parseTaskListParams(c)
 	if err != nil {
-		log.Printf("Error querying tasks: %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve tasks"})
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
-	defer rows.Close()
+	filter := params.toFilter(userID)
 
 	var tasks []models.Task
-	for rows.Next() {
-		var task models.Task
-		var createdAt, updatedAt time.Time
-		if err := rows.Scan(&task.ID, &task.Title, &task.DueDate, &task.Completed, &createdAt, &updatedAt); err != nil {
-			log.Printf("Error scanning task row: %v", err)
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to process tasks"})
-			return
-		}
-		task.CreatedAt = createdAt
-		task.UpdatedAt = updatedAt
-		tasks = append(tasks, task)
+	var total int
+	var listErr, countErr error
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+
+	go func() {
+		defer wg.Done()
+		tasks, listErr = h.Tasks.List(c.Request.Context(), filter)
+	}()
+
+	go func() {
+		defer wg.Done()
+		total, countErr = h.Tasks.Count(c.Request.Context(), filter)
+	}()
+
+	wg.Wait()
+
+	if listErr != nil {
+		log.Printf("Error querying tasks: %v", listErr)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve tasks"})
+		return
 	}
+	if countErr != nil {
+		log.Printf("Error counting tasks: %v", countErr)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve tasks"})
+		return
+	}
+
+	c.Header("X-Total-Count", strconv.Itoa(total))
+	c.Header("Link", buildLinkHeader(c, params, total))
 
-	if err := rows.Err(); err != nil {
-		log.Printf("Error iterating task rows: %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to process tasks"})
+	if isListV2Request(c) {
+		c.JSON(http.StatusOK, gin.H{
+			"items":    tasks,
+			"page":     params.page,
+			"pageSize": params.pageSize,
+			"total":    total,
+		})
 		return
 	}
 
 	c.JSON(http.StatusOK, tasks)
 }
 
+// buildLinkHeader builds an RFC 5988 Link header with rel="next"/"prev"/"first"/"last"
+// URLs for the current request, based on the total item count.
+func buildLinkHeader(c *gin.Context, params taskListParams, total int) string {
+	lastPage := (total + params.pageSize - 1) / params.pageSize
+	if lastPage < 1 {
+		lastPage = 1
+	}
+
+	urlForPage := func(page int) string {
+		u := *c.Request.URL
+		q := u.Query()
+		q.Set("page", strconv.Itoa(page))
+		q.Set("page_size", strconv.Itoa(params.pageSize))
+		u.RawQuery = q.Encode()
+		return u.String()
+	}
+
+	links := []string{
+		fmt.Sprintf(`<%s>; rel="first"`, urlForPage(1)),
+		fmt.Sprintf(`<%s>; rel="last"`, urlForPage(lastPage)),
+	}
+	if params.page > 1 {
+		links = append(links, fmt.Sprintf(`<%s>; rel="prev"`, urlForPage(params.page-1)))
+	}
+	if params.page < lastPage {
+		links = append(links, fmt.Sprintf(`<%s>; rel="next"`, urlForPage(params.page+1)))
+	}
+
+	return strings.Join(links, ", ")
+}
+
 func (h *TaskHandler) GetTask(c *gin.Context) {
 	id := c.Param("id")
-	
-	var task models.Task
-	var createdAt, updatedAt time.Time
-	
-	err := h.DB.QueryRow(`
-		SELECT id, title, due_date, completed, created_at, updated_at 
-		FROM tasks 
-		WHERE id = ?
-	`, id).Scan(&task.ID, &task.Title, &task.DueDate, &task.Completed, &createdAt, &updatedAt)
-	
-	if err == sql.ErrNoRows {
+	userID := c.GetString(middleware.UserIDKey)
+
+	task, err := h.Tasks.Get(c.Request.Context(), id, userID)
+	if errors.Is(err, repository.ErrNotFound) {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Task not found"})
 		return
 	} else if err != nil {
@@ -75,10 +266,7 @@ func (h *TaskHandler) GetTask(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve task"})
 		return
 	}
-	
-	task.CreatedAt = createdAt
-	task.UpdatedAt = updatedAt
-	
+
 	c.JSON(http.StatusOK, task)
 }
 
@@ -89,99 +277,108 @@ func (h *TaskHandler) CreateTask(c *gin.Context) {
 		return
 	}
 
+	if req.RecurrenceRule != "" {
+		if _, err := scheduler.ParseRRule(req.RecurrenceRule); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid recurrenceRule: " + err.Error()})
+			return
+		}
+	}
+
+	userID := c.GetString(middleware.UserIDKey)
 	now := time.Now()
-	id := uuid.New().String()
 
-	_, err := h.DB.Exec(`
-		INSERT INTO tasks (id, title, due_date, completed, created_at, updated_at) 
-		VALUES (?, ?, ?, ?, ?, ?)
-	`, id, req.Title, req.DueDate, req.Completed, now, now)
-	
-	if err != nil {
+	task := models.Task{
+		ID:             uuid.New().String(),
+		UserID:         userID,
+		Title:          req.Title,
+		DueDate:        req.DueDate,
+		Completed:      req.Completed,
+		RecurrenceRule: req.RecurrenceRule,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+
+	if err := h.Tasks.Create(c.Request.Context(), task); err != nil {
 		log.Printf("Error creating task: %v", err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create task"})
 		return
 	}
 
-	task := models.Task{
-		ID:        id,
-		Title:     req.Title,
-		DueDate:   req.DueDate,
-		Completed: req.Completed,
-		CreatedAt: now,
-		UpdatedAt: now,
-	}
+	h.scheduleIfRecurring(task.ID, userID, req.RecurrenceRule, req.DueDate)
+	h.publishEvent(hooks.EventTaskCreated, userID, task)
 
 	c.JSON(http.StatusCreated, task)
 }
 
 func (h *TaskHandler) UpdateTask(c *gin.Context) {
 	id := c.Param("id")
-	
+	userID := c.GetString(middleware.UserIDKey)
+
 	var req models.TaskRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	now := time.Now()
+	if req.RecurrenceRule != "" {
+		if _, err := scheduler.ParseRRule(req.RecurrenceRule); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid recurrenceRule: " + err.Error()})
+			return
+		}
+	}
 
-	result, err := h.DB.Exec(`
-		UPDATE tasks 
-		SET title = ?, due_date = ?, completed = ?, updated_at = ? 
-		WHERE id = ?
-	`, req.Title, req.DueDate, req.Completed, now, id)
-	
-	if err != nil {
-		log.Printf("Error updating task: %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update task"})
-		return
+	now := time.Now()
+	task := models.Task{
+		ID:             id,
+		UserID:         userID,
+		Title:          req.Title,
+		DueDate:        req.DueDate,
+		Completed:      req.Completed,
+		RecurrenceRule: req.RecurrenceRule,
+		UpdatedAt:      now,
 	}
 
-	rowsAffected, err := result.RowsAffected()
+	found, err := h.Tasks.Update(c.Request.Context(), task)
 	if err != nil {
-		log.Printf("Error getting rows affected: %v", err)
+		log.Printf("Error updating task: %v", err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update task"})
 		return
 	}
-
-	if rowsAffected == 0 {
+	if !found {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Task not found"})
 		return
 	}
 
-	task := models.Task{
-		ID:        id,
-		Title:     req.Title,
-		DueDate:   req.DueDate,
-		Completed: req.Completed,
-		UpdatedAt: now,
+	h.scheduleIfRecurring(id, userID, req.RecurrenceRule, req.DueDate)
+
+	eventType := hooks.EventTaskUpdated
+	if task.Completed {
+		eventType = hooks.EventTaskCompleted
 	}
+	h.publishEvent(eventType, userID, task)
 
 	c.JSON(http.StatusOK, task)
 }
 
 func (h *TaskHandler) DeleteTask(c *gin.Context) {
 	id := c.Param("id")
+	userID := c.GetString(middleware.UserIDKey)
 
-	result, err := h.DB.Exec("DELETE FROM tasks WHERE id = ?", id)
+	found, err := h.Tasks.Delete(c.Request.Context(), id, userID)
 	if err != nil {
 		log.Printf("Error deleting task: %v", err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete task"})
 		return
 	}
-
-	rowsAffected, err := result.RowsAffected()
-	if err != nil {
-		log.Printf("Error getting rows affected: %v", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete task"})
+	if !found {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Task not found"})
 		return
 	}
 
-	if rowsAffected == 0 {
-		c.JSON(http.StatusNotFound, gin.H{"error": "Task not found"})
-		return
+	if h.Scheduler != nil {
+		h.Scheduler.Cancel(id)
 	}
+	h.publishEvent(hooks.EventTaskDeleted, userID, models.Task{ID: id, UserID: userID})
 
 	c.JSON(http.StatusOK, gin.H{"message": "Task deleted successfully"})
 }
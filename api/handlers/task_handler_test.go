@@ -2,274 +2,380 @@ package handlers
 
 import (
 	"bytes"
-	"database/sql"
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/gin-gonic/gin"
-	"github.com/google/uuid"
+	"github.com/k-satosi/todo-app-webui/api/middleware"
 	"github.com/k-satosi/todo-app-webui/api/models"
+	"github.com/k-satosi/todo-app-webui/api/repository"
 	"github.com/stretchr/testify/assert"
-	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
-type DBInterface interface {
-	Exec(query string, args ...interface{}) (sql.Result, error)
-	Query(query string, args ...interface{}) (*sql.Rows, error)
-	QueryRow(query string, args ...interface{}) *sql.Row
-	Close() error
-	Ping() error
-}
+const testUserID = "test-user-1"
 
-type MockDB struct {
-	mock.Mock
+// withMockAuth injects an authenticated userID into the context, standing in
+// for middleware.RequireAuth in tests that don't exercise the middleware itself.
+func withMockAuth(r *gin.Engine) {
+	r.Use(func(c *gin.Context) {
+		c.Set(middleware.UserIDKey, testUserID)
+		c.Next()
+	})
 }
 
-func (m *MockDB) Exec(query string, args ...interface{}) (sql.Result, error) {
-	args = append([]interface{}{query}, args...)
-	called := m.Called(args...)
-	return called.Get(0).(sql.Result), called.Error(1)
+// inMemoryTaskRepository is a hand-written fake standing in for a real
+// database-backed TaskRepository in handler tests, mirroring the
+// mock-usecase pattern from clean-architecture Go backends.
+type inMemoryTaskRepository struct {
+	mu    sync.Mutex
+	tasks map[string]models.Task
 }
 
-func (m *MockDB) Query(query string, args ...interface{}) (*sql.Rows, error) {
-	args = append([]interface{}{query}, args...)
-	called := m.Called(args...)
-	return called.Get(0).(*sql.Rows), called.Error(1)
+func newInMemoryTaskRepository(seed ...models.Task) *inMemoryTaskRepository {
+	repo := &inMemoryTaskRepository{tasks: make(map[string]models.Task)}
+	for _, task := range seed {
+		repo.tasks[task.ID] = task
+	}
+	return repo
 }
 
-func (m *MockDB) QueryRow(query string, args ...interface{}) *sql.Row {
-	args = append([]interface{}{query}, args...)
-	called := m.Called(args...)
-	return called.Get(0).(*sql.Row)
+func (r *inMemoryTaskRepository) matches(task models.Task, filter repository.TaskFilter) bool {
+	if task.UserID != filter.UserID {
+		return false
+	}
+	switch filter.Status {
+	case "completed":
+		if !task.Completed {
+			return false
+		}
+	case "pending":
+		if task.Completed {
+			return false
+		}
+	case "overdue":
+		if task.Completed || !task.DueDate.Before(time.Now()) {
+			return false
+		}
+	}
+	if filter.Query != "" && !strings.Contains(task.Title, filter.Query) {
+		return false
+	}
+	if filter.DueBefore != nil && !task.DueDate.Before(*filter.DueBefore) {
+		return false
+	}
+	if filter.DueAfter != nil && !task.DueDate.After(*filter.DueAfter) {
+		return false
+	}
+	return true
 }
 
-func (m *MockDB) Close() error {
-	called := m.Called()
-	return called.Error(0)
-}
+func (r *inMemoryTaskRepository) filtered(filter repository.TaskFilter) []models.Task {
+	r.mu.Lock()
+	defer r.mu.Unlock()
 
-func (m *MockDB) Ping() error {
-	called := m.Called()
-	return called.Error(0)
+	var matched []models.Task
+	for _, task := range r.tasks {
+		if r.matches(task, filter) {
+			matched = append(matched, task)
+		}
+	}
+	return matched
 }
 
-type MockResult struct {
-	AffectedRows int64
-	InsertID     int64
-}
+func (r *inMemoryTaskRepository) List(ctx context.Context, filter repository.TaskFilter) ([]models.Task, error) {
+	matched := r.filtered(filter)
 
-func (m MockResult) LastInsertId() (int64, error) {
-	return m.InsertID, nil
+	start := filter.Offset
+	if start > len(matched) {
+		start = len(matched)
+	}
+	end := start + filter.Limit
+	if filter.Limit == 0 || end > len(matched) {
+		end = len(matched)
+	}
+	return matched[start:end], nil
 }
 
-func (m MockResult) RowsAffected() (int64, error) {
-	return m.AffectedRows, nil
+func (r *inMemoryTaskRepository) Count(ctx context.Context, filter repository.TaskFilter) (int, error) {
+	return len(r.filtered(filter)), nil
 }
 
-type MockRows struct {
-	mock.Mock
-	Rows [][]interface{}
-	Pos  int
-}
+func (r *inMemoryTaskRepository) Get(ctx context.Context, id, userID string) (models.Task, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
 
-func (m *MockRows) Next() bool {
-	return m.Pos < len(m.Rows)
+	task, ok := r.tasks[id]
+	if !ok || task.UserID != userID {
+		return models.Task{}, repository.ErrNotFound
+	}
+	return task, nil
 }
 
-func (m *MockRows) Scan(dest ...interface{}) error {
-	for i, d := range dest {
-		switch v := d.(type) {
-		case *string:
-			*v = m.Rows[m.Pos][i].(string)
-		case *time.Time:
-			*v = m.Rows[m.Pos][i].(time.Time)
-		case *bool:
-			*v = m.Rows[m.Pos][i].(bool)
-		}
-	}
-	m.Pos++
+func (r *inMemoryTaskRepository) Create(ctx context.Context, task models.Task) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.tasks[task.ID] = task
 	return nil
 }
 
-func (m *MockRows) Close() error {
-	return nil
+func (r *inMemoryTaskRepository) Update(ctx context.Context, task models.Task) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, ok := r.tasks[task.ID]
+	if !ok || existing.UserID != task.UserID {
+		return false, nil
+	}
+	task.CreatedAt = existing.CreatedAt
+	r.tasks[task.ID] = task
+	return true, nil
 }
 
-func (m *MockRows) Err() error {
-	return nil
+func (r *inMemoryTaskRepository) Delete(ctx context.Context, id, userID string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, ok := r.tasks[id]
+	if !ok || existing.UserID != userID {
+		return false, nil
+	}
+	delete(r.tasks, id)
+	return true, nil
 }
 
-func (m *MockRows) Columns() ([]string, error) {
-	return []string{"id", "title", "due_date", "completed", "created_at", "updated_at"}, nil
+func TestParseTaskListParams(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		wantErr  bool
+		wantPage int
+		wantSize int
+		wantSort []string
+		wantStat string
+	}{
+		{name: "defaults", query: "", wantPage: 1, wantSize: defaultPageSize, wantSort: []string{"due_date"}},
+		{name: "page and page_size", query: "page=2&page_size=50", wantPage: 2, wantSize: 50, wantSort: []string{"due_date"}},
+		{name: "status filter", query: "status=completed", wantPage: 1, wantSize: defaultPageSize, wantSort: []string{"due_date"}, wantStat: "completed"},
+		{name: "q and sort", query: "q=groceries&sort=-due_date,title", wantPage: 1, wantSize: defaultPageSize, wantSort: []string{"-due_date", "title"}},
+		{name: "invalid status", query: "status=bogus", wantErr: true},
+		{name: "invalid sort field", query: "sort=password", wantErr: true},
+		{name: "invalid page", query: "page=0", wantErr: true},
+		{name: "page_size too large", query: "page_size=1000", wantErr: true},
+		{name: "invalid due_before", query: "due_before=not-a-date", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest("GET", "/tasks?"+tt.query, nil)
+
+			params, err := parseTaskListParams(c)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantPage, params.page)
+			assert.Equal(t, tt.wantSize, params.pageSize)
+			assert.Equal(t, tt.wantSort, params.sort)
+			assert.Equal(t, tt.wantStat, params.status)
+		})
+	}
 }
 
 func TestGetTasks(t *testing.T) {
 	gin.SetMode(gin.TestMode)
-	
+
 	r := gin.Default()
-	
-	mockDB := new(MockDB)
-	
+	withMockAuth(r)
+
 	now := time.Now()
-	mockRows := &MockRows{
-		Rows: [][]interface{}{
-			{"1", "Task 1", now, false, now, now},
-			{"2", "Task 2", now.Add(24 * time.Hour), true, now, now},
-		},
-	}
-	
-	mockDB.On("Query", mock.Anything).Return(mockRows, nil)
-	
-	handler := &TaskHandler{DB: mockDB}
-	
+	repo := newInMemoryTaskRepository(
+		models.Task{ID: "1", UserID: testUserID, Title: "Task 1", DueDate: now, CreatedAt: now, UpdatedAt: now},
+		models.Task{ID: "2", UserID: testUserID, Title: "Task 2", DueDate: now.Add(24 * time.Hour), Completed: true, CreatedAt: now, UpdatedAt: now},
+		models.Task{ID: "3", UserID: "someone-else", Title: "Not mine", DueDate: now, CreatedAt: now, UpdatedAt: now},
+	)
+
+	handler := &TaskHandler{Tasks: repo}
 	r.GET("/tasks", handler.GetTasks)
-	
+
 	req, _ := http.NewRequest("GET", "/tasks", nil)
 	w := httptest.NewRecorder()
-	
 	r.ServeHTTP(w, req)
-	
+
 	assert.Equal(t, http.StatusOK, w.Code)
-	
+	assert.Equal(t, "2", w.Header().Get("X-Total-Count"))
+
 	var tasks []models.Task
 	err := json.Unmarshal(w.Body.Bytes(), &tasks)
-	assert.NoError(t, err)
+	require.NoError(t, err)
 	assert.Len(t, tasks, 2)
-	assert.Equal(t, "Task 1", tasks[0].Title)
-	assert.Equal(t, "Task 2", tasks[1].Title)
-	
-	mockDB.AssertExpectations(t)
 }
 
 func TestGetTask(t *testing.T) {
 	gin.SetMode(gin.TestMode)
-	
+
 	r := gin.Default()
-	
-	mockDB := new(MockDB)
-	
+	withMockAuth(r)
+
 	now := time.Now()
-	mockRow := &sql.Row{}
-	
-	mockDB.On("QueryRow", mock.Anything, mock.Anything).Return(mockRow)
-	
-	handler := &TaskHandler{DB: mockDB}
-	
+	repo := newInMemoryTaskRepository(
+		models.Task{ID: "123", UserID: testUserID, Title: "Task 123", DueDate: now, CreatedAt: now, UpdatedAt: now},
+	)
+
+	handler := &TaskHandler{Tasks: repo}
 	r.GET("/tasks/:id", handler.GetTask)
-	
+
 	req, _ := http.NewRequest("GET", "/tasks/123", nil)
 	w := httptest.NewRecorder()
-	
 	r.ServeHTTP(w, req)
-	
-	mockDB.AssertExpectations(t)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var task models.Task
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &task))
+	assert.Equal(t, "Task 123", task.Title)
+}
+
+func TestGetTask_NotFound(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	r := gin.Default()
+	withMockAuth(r)
+
+	handler := &TaskHandler{Tasks: newInMemoryTaskRepository()}
+	r.GET("/tasks/:id", handler.GetTask)
+
+	req, _ := http.NewRequest("GET", "/tasks/missing", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
 }
 
 func TestCreateTask(t *testing.T) {
 	gin.SetMode(gin.TestMode)
-	
+
 	r := gin.Default()
-	
-	mockDB := new(MockDB)
-	
-	mockResult := MockResult{AffectedRows: 1, InsertID: 1}
-	mockDB.On("Exec", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(mockResult, nil)
-	
-	handler := &TaskHandler{DB: mockDB}
-	
+	withMockAuth(r)
+
+	handler := &TaskHandler{Tasks: newInMemoryTaskRepository()}
 	r.POST("/tasks", handler.CreateTask)
-	
+
 	task := models.TaskRequest{
 		Title:     "Test Task",
 		DueDate:   time.Now().Add(24 * time.Hour),
 		Completed: false,
 	}
-	
+
 	taskJSON, _ := json.Marshal(task)
 	req, _ := http.NewRequest("POST", "/tasks", bytes.NewBuffer(taskJSON))
 	req.Header.Set("Content-Type", "application/json")
 	w := httptest.NewRecorder()
-	
+
 	r.ServeHTTP(w, req)
-	
+
 	assert.Equal(t, http.StatusCreated, w.Code)
-	
+
 	var createdTask models.Task
 	err := json.Unmarshal(w.Body.Bytes(), &createdTask)
-	assert.NoError(t, err)
+	require.NoError(t, err)
 	assert.Equal(t, task.Title, createdTask.Title)
 	assert.Equal(t, task.Completed, createdTask.Completed)
-	
-	mockDB.AssertExpectations(t)
+	assert.Equal(t, testUserID, createdTask.UserID)
 }
 
 func TestUpdateTask(t *testing.T) {
 	gin.SetMode(gin.TestMode)
-	
+
 	r := gin.Default()
-	
-	mockDB := new(MockDB)
-	
-	mockResult := MockResult{AffectedRows: 1, InsertID: 0}
-	mockDB.On("Exec", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(mockResult, nil)
-	
-	handler := &TaskHandler{DB: mockDB}
-	
+	withMockAuth(r)
+
+	now := time.Now()
+	repo := newInMemoryTaskRepository(
+		models.Task{ID: "123", UserID: testUserID, Title: "Old title", DueDate: now, CreatedAt: now, UpdatedAt: now},
+	)
+
+	handler := &TaskHandler{Tasks: repo}
 	r.PUT("/tasks/:id", handler.UpdateTask)
-	
+
 	task := models.TaskRequest{
 		Title:     "Updated Task",
 		DueDate:   time.Now().Add(24 * time.Hour),
 		Completed: true,
 	}
-	
+
 	taskJSON, _ := json.Marshal(task)
 	req, _ := http.NewRequest("PUT", "/tasks/123", bytes.NewBuffer(taskJSON))
 	req.Header.Set("Content-Type", "application/json")
 	w := httptest.NewRecorder()
-	
+
 	r.ServeHTTP(w, req)
-	
+
 	assert.Equal(t, http.StatusOK, w.Code)
-	
+
 	var updatedTask models.Task
 	err := json.Unmarshal(w.Body.Bytes(), &updatedTask)
-	assert.NoError(t, err)
+	require.NoError(t, err)
 	assert.Equal(t, task.Title, updatedTask.Title)
 	assert.Equal(t, task.Completed, updatedTask.Completed)
 	assert.Equal(t, "123", updatedTask.ID)
-	
-	mockDB.AssertExpectations(t)
+}
+
+func TestUpdateTask_NotFound(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	r := gin.Default()
+	withMockAuth(r)
+
+	handler := &TaskHandler{Tasks: newInMemoryTaskRepository()}
+	r.PUT("/tasks/:id", handler.UpdateTask)
+
+	task := models.TaskRequest{Title: "Updated Task", DueDate: time.Now().Add(24 * time.Hour)}
+	taskJSON, _ := json.Marshal(task)
+	req, _ := http.NewRequest("PUT", "/tasks/missing", bytes.NewBuffer(taskJSON))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
 }
 
 func TestDeleteTask(t *testing.T) {
 	gin.SetMode(gin.TestMode)
-	
+
 	r := gin.Default()
-	
-	mockDB := new(MockDB)
-	
-	mockResult := MockResult{AffectedRows: 1, InsertID: 0}
-	mockDB.On("Exec", mock.Anything, mock.Anything).Return(mockResult, nil)
-	
-	handler := &TaskHandler{DB: mockDB}
-	
+	withMockAuth(r)
+
+	now := time.Now()
+	repo := newInMemoryTaskRepository(
+		models.Task{ID: "123", UserID: testUserID, Title: "Task 123", DueDate: now, CreatedAt: now, UpdatedAt: now},
+	)
+
+	handler := &TaskHandler{Tasks: repo}
 	r.DELETE("/tasks/:id", handler.DeleteTask)
-	
+
 	req, _ := http.NewRequest("DELETE", "/tasks/123", nil)
 	w := httptest.NewRecorder()
-	
+
 	r.ServeHTTP(w, req)
-	
+
 	assert.Equal(t, http.StatusOK, w.Code)
-	
+
 	var response map[string]string
 	err := json.Unmarshal(w.Body.Bytes(), &response)
-	assert.NoError(t, err)
+	require.NoError(t, err)
 	assert.Equal(t, "Task deleted successfully", response["message"])
-	
-	mockDB.AssertExpectations(t)
+
+	_, err = repo.Get(context.Background(), "123", testUserID)
+	assert.ErrorIs(t, err, repository.ErrNotFound)
 }
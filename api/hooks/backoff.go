@@ -0,0 +1,29 @@
+package hooks
+
+import "time"
+
+// backoffSchedule gives the delay before each retry attempt; the last entry
+// repeats for any further attempt until maxRetryWindow is reached.
+var backoffSchedule = []time.Duration{
+	1 * time.Second,
+	5 * time.Second,
+	30 * time.Second,
+	5 * time.Minute,
+	30 * time.Minute,
+}
+
+// maxRetryWindow bounds how long a delivery is retried after its first
+// attempt before it's given up on and marked exhausted.
+const maxRetryWindow = 24 * time.Hour
+
+// nextBackoff returns the delay before the given retry attempt (1-indexed:
+// attempt 1 is the delay before the first retry, after the initial send).
+func nextBackoff(attempt int) time.Duration {
+	if attempt < 1 {
+		attempt = 1
+	}
+	if attempt > len(backoffSchedule) {
+		return backoffSchedule[len(backoffSchedule)-1]
+	}
+	return backoffSchedule[attempt-1]
+}
@@ -0,0 +1,14 @@
+package hooks
+
+import "time"
+
+// Clock abstracts time so delivery timestamps, and the retry-window check
+// based on them, are deterministic in tests. Backoff delays themselves run
+// on real timers.
+type Clock interface {
+	Now() time.Time
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time { return time.Now() }
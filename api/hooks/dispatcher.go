@@ -0,0 +1,330 @@
+package hooks
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/k-satosi/todo-app-webui/api/database"
+	"github.com/k-satosi/todo-app-webui/api/models"
+)
+
+// payloadEnvelope is the JSON body POSTed to a hook's URL.
+type payloadEnvelope struct {
+	Event      string      `json:"event"`
+	Task       models.Task `json:"task"`
+	OccurredAt time.Time   `json:"occurredAt"`
+}
+
+// Dispatcher publishes task lifecycle Events onto a buffered channel drained
+// by a pool of worker goroutines, each of which signs and POSTs the payload
+// to every matching Hook and retries failed deliveries with exponential
+// backoff up to maxRetryWindow.
+type Dispatcher struct {
+	store  store
+	clock  Clock
+	client *http.Client
+
+	events chan Event
+
+	workers   int
+	workersWg sync.WaitGroup
+
+	deliveriesWg sync.WaitGroup
+
+	retriesMu sync.Mutex
+	retries   map[*time.Timer]struct{}
+
+	stopCh   chan struct{}
+	stopOnce sync.Once
+}
+
+// NewDispatcher constructs a Dispatcher backed by db, running workers
+// concurrent delivery workers.
+func NewDispatcher(db *sql.DB, dialect database.Dialect, workers int) *Dispatcher {
+	return newDispatcher(&sqlStore{db: db, dialect: dialect}, realClock{}, workers)
+}
+
+func newDispatcher(s store, clock Clock, workers int) *Dispatcher {
+	if clock == nil {
+		clock = realClock{}
+	}
+	if workers < 1 {
+		workers = 1
+	}
+	return &Dispatcher{
+		store:   s,
+		clock:   clock,
+		client:  &http.Client{Timeout: 10 * time.Second},
+		events:  make(chan Event, 256),
+		workers: workers,
+		retries: make(map[*time.Timer]struct{}),
+		stopCh:  make(chan struct{}),
+	}
+}
+
+// Start launches the worker pool and resumes any deliveries that were still
+// pending retry when the process last stopped.
+func (d *Dispatcher) Start(ctx context.Context) error {
+	for i := 0; i < d.workers; i++ {
+		d.workersWg.Add(1)
+		go d.worker(ctx)
+	}
+
+	pending, err := d.store.pendingDeliveries(ctx)
+	if err != nil {
+		return err
+	}
+	for _, delivery := range pending {
+		d.resumeRetry(ctx, delivery)
+	}
+	return nil
+}
+
+func (d *Dispatcher) worker(ctx context.Context) {
+	defer d.workersWg.Done()
+	for {
+		select {
+		case <-d.stopCh:
+			return
+		case event := <-d.events:
+			d.handleEvent(ctx, event)
+		}
+	}
+}
+
+// RegisterHook stores a new webhook registration for userID.
+func (d *Dispatcher) RegisterHook(ctx context.Context, userID string, req models.HookRequest) (models.Hook, error) {
+	now := d.clock.Now()
+	hook := models.Hook{
+		ID:        uuid.New().String(),
+		UserID:    userID,
+		URL:       req.URL,
+		Secret:    req.Secret,
+		Events:    req.Events,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := d.store.insertHook(ctx, hook); err != nil {
+		return models.Hook{}, err
+	}
+	return hook, nil
+}
+
+// ListDeliveries returns the delivery history for a hook owned by userID.
+func (d *Dispatcher) ListDeliveries(ctx context.Context, hookID, userID string) ([]models.Delivery, error) {
+	if _, err := d.store.getHook(ctx, hookID, userID); err != nil {
+		return nil, err
+	}
+	return d.store.listDeliveries(ctx, hookID)
+}
+
+// RetryDelivery re-attempts a delivery immediately, regardless of its
+// scheduled next_retry_at.
+func (d *Dispatcher) RetryDelivery(ctx context.Context, deliveryID, userID string) (models.Delivery, error) {
+	delivery, err := d.store.getDelivery(ctx, deliveryID)
+	if err != nil {
+		return models.Delivery{}, err
+	}
+	hook, err := d.store.getHook(ctx, delivery.HookID, userID)
+	if err != nil {
+		return models.Delivery{}, err
+	}
+
+	d.deliveriesWg.Add(1)
+	go func() {
+		defer d.deliveriesWg.Done()
+		d.attemptDelivery(context.Background(), hook, delivery)
+	}()
+
+	return delivery, nil
+}
+
+// Publish enqueues event for asynchronous delivery. It does not block once
+// shutdown has begun.
+func (d *Dispatcher) Publish(event Event) {
+	select {
+	case d.events <- event:
+	case <-d.stopCh:
+	}
+}
+
+func (d *Dispatcher) handleEvent(ctx context.Context, event Event) {
+	matched, err := d.store.hooksForEvent(ctx, event.UserID, event.Type)
+	if err != nil {
+		log.Printf("Error loading hooks for event %s: %v", event.Type, err)
+		return
+	}
+
+	for _, hook := range matched {
+		payload, err := json.Marshal(payloadEnvelope{Event: event.Type, Task: event.Task, OccurredAt: d.clock.Now()})
+		if err != nil {
+			log.Printf("Error marshaling payload for hook %s: %v", hook.ID, err)
+			continue
+		}
+
+		now := d.clock.Now()
+		delivery := models.Delivery{
+			ID:        uuid.New().String(),
+			HookID:    hook.ID,
+			Event:     event.Type,
+			Payload:   string(payload),
+			Status:    models.DeliveryPending,
+			Attempt:   1,
+			CreatedAt: now,
+			UpdatedAt: now,
+		}
+		if err := d.store.insertDelivery(ctx, delivery); err != nil {
+			log.Printf("Error recording delivery for hook %s: %v", hook.ID, err)
+			continue
+		}
+
+		d.attemptDelivery(ctx, hook, delivery)
+	}
+}
+
+// attemptDelivery POSTs delivery.Payload to hook.URL, records the outcome,
+// and schedules a retry with backoff on failure.
+func (d *Dispatcher) attemptDelivery(ctx context.Context, hook models.Hook, delivery models.Delivery) {
+	signature := "sha256=" + sign(hook.Secret, []byte(delivery.Payload))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, hook.URL, bytes.NewReader([]byte(delivery.Payload)))
+	if err != nil {
+		log.Printf("Error building request for delivery %s: %v", delivery.ID, err)
+		return
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Signature", signature)
+
+	resp, doErr := d.client.Do(req)
+	success := doErr == nil && resp != nil && resp.StatusCode >= 200 && resp.StatusCode < 300
+
+	delivery.UpdatedAt = d.clock.Now()
+	if resp != nil {
+		delivery.StatusCode = resp.StatusCode
+		resp.Body.Close()
+	}
+	if doErr != nil {
+		delivery.Error = doErr.Error()
+	} else {
+		delivery.Error = ""
+	}
+
+	if success {
+		delivery.Status = models.DeliverySucceeded
+		delivery.NextRetryAt = nil
+		if err := d.store.updateDelivery(ctx, delivery); err != nil {
+			log.Printf("Error recording successful delivery %s: %v", delivery.ID, err)
+		}
+		return
+	}
+
+	elapsed := delivery.UpdatedAt.Sub(delivery.CreatedAt)
+	backoff := nextBackoff(delivery.Attempt)
+	if elapsed+backoff > maxRetryWindow {
+		delivery.Status = models.DeliveryExhausted
+		delivery.NextRetryAt = nil
+		if err := d.store.updateDelivery(ctx, delivery); err != nil {
+			log.Printf("Error recording exhausted delivery %s: %v", delivery.ID, err)
+		}
+		return
+	}
+
+	delivery.Status = models.DeliveryFailed
+	next := delivery.UpdatedAt.Add(backoff)
+	delivery.NextRetryAt = &next
+	delivery.Attempt++
+	if err := d.store.updateDelivery(ctx, delivery); err != nil {
+		log.Printf("Error recording failed delivery %s: %v", delivery.ID, err)
+		return
+	}
+
+	d.scheduleRetry(hook, delivery, backoff)
+}
+
+// scheduleRetry arms a timer to fire delivery again after delay, tracking it
+// so Stop can cancel it outright instead of blocking for the full backoff:
+// the delivery's next_retry_at is already persisted, so a cancelled retry is
+// simply picked back up by pendingDeliveries on the next process start.
+func (d *Dispatcher) scheduleRetry(hook models.Hook, delivery models.Delivery, delay time.Duration) {
+	d.retriesMu.Lock()
+	defer d.retriesMu.Unlock()
+
+	// Stop closes stopCh before it collects d.retries, so a retry armed after
+	// that would never be cancelled; it is left to pendingDeliveries instead.
+	select {
+	case <-d.stopCh:
+		return
+	default:
+	}
+
+	d.deliveriesWg.Add(1)
+
+	var timer *time.Timer
+	timer = time.AfterFunc(delay, func() {
+		defer d.deliveriesWg.Done()
+
+		d.retriesMu.Lock()
+		delete(d.retries, timer)
+		d.retriesMu.Unlock()
+
+		select {
+		case <-d.stopCh:
+			return
+		default:
+		}
+		d.attemptDelivery(context.Background(), hook, delivery)
+	})
+	d.retries[timer] = struct{}{}
+}
+
+// resumeRetry re-schedules a delivery that was still pending retry when the
+// process last started up, honoring whatever time remains before its
+// next_retry_at.
+func (d *Dispatcher) resumeRetry(ctx context.Context, delivery models.Delivery) {
+	hook, err := d.store.getHookByID(ctx, delivery.HookID)
+	if err != nil {
+		log.Printf("Error loading hook %s to resume delivery %s: %v", delivery.HookID, delivery.ID, err)
+		return
+	}
+
+	delay := time.Duration(0)
+	if delivery.NextRetryAt != nil {
+		delay = delivery.NextRetryAt.Sub(d.clock.Now())
+		if delay < 0 {
+			delay = 0
+		}
+	}
+	d.scheduleRetry(hook, delivery, delay)
+}
+
+// Stop signals the dispatcher to stop accepting new deliveries, cancels any
+// retries still waiting on their backoff timer, and waits for the worker
+// pool and any delivery already in flight to finish.
+func (d *Dispatcher) Stop() {
+	d.stopOnce.Do(func() {
+		close(d.stopCh)
+	})
+
+	d.retriesMu.Lock()
+	timers := make([]*time.Timer, 0, len(d.retries))
+	for timer := range d.retries {
+		timers = append(timers, timer)
+	}
+	d.retriesMu.Unlock()
+
+	for _, timer := range timers {
+		if timer.Stop() {
+			d.deliveriesWg.Done()
+		}
+	}
+
+	d.workersWg.Wait()
+	d.deliveriesWg.Wait()
+}
@@ -0,0 +1,284 @@
+package hooks
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/k-satosi/todo-app-webui/api/models"
+)
+
+// fakeStore is an in-memory stand-in for sqlStore, so Dispatcher can be
+// tested without a real database.
+type fakeStore struct {
+	mu         sync.Mutex
+	hooks      map[string]models.Hook
+	deliveries map[string]models.Delivery
+	updates    chan models.Delivery
+}
+
+func newFakeStore(hooks ...models.Hook) *fakeStore {
+	s := &fakeStore{
+		hooks:      make(map[string]models.Hook),
+		deliveries: make(map[string]models.Delivery),
+		updates:    make(chan models.Delivery, 16),
+	}
+	for _, h := range hooks {
+		s.hooks[h.ID] = h
+	}
+	return s
+}
+
+func (s *fakeStore) insertHook(ctx context.Context, hook models.Hook) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.hooks[hook.ID] = hook
+	return nil
+}
+
+func (s *fakeStore) hooksForEvent(ctx context.Context, userID, eventType string) ([]models.Hook, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var matched []models.Hook
+	for _, h := range s.hooks {
+		if h.UserID != userID {
+			continue
+		}
+		for _, e := range h.Events {
+			if e == eventType {
+				matched = append(matched, h)
+				break
+			}
+		}
+	}
+	return matched, nil
+}
+
+func (s *fakeStore) getHook(ctx context.Context, id, userID string) (models.Hook, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	h, ok := s.hooks[id]
+	if !ok || h.UserID != userID {
+		return models.Hook{}, errNotFound
+	}
+	return h, nil
+}
+
+func (s *fakeStore) getHookByID(ctx context.Context, id string) (models.Hook, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	h, ok := s.hooks[id]
+	if !ok {
+		return models.Hook{}, errNotFound
+	}
+	return h, nil
+}
+
+func (s *fakeStore) insertDelivery(ctx context.Context, delivery models.Delivery) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.deliveries[delivery.ID] = delivery
+	return nil
+}
+
+func (s *fakeStore) updateDelivery(ctx context.Context, delivery models.Delivery) error {
+	s.mu.Lock()
+	s.deliveries[delivery.ID] = delivery
+	s.mu.Unlock()
+	s.updates <- delivery
+	return nil
+}
+
+func (s *fakeStore) listDeliveries(ctx context.Context, hookID string) ([]models.Delivery, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var matched []models.Delivery
+	for _, d := range s.deliveries {
+		if d.HookID == hookID {
+			matched = append(matched, d)
+		}
+	}
+	return matched, nil
+}
+
+func (s *fakeStore) getDelivery(ctx context.Context, id string) (models.Delivery, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	d, ok := s.deliveries[id]
+	if !ok {
+		return models.Delivery{}, errNotFound
+	}
+	return d, nil
+}
+
+func (s *fakeStore) pendingDeliveries(ctx context.Context) ([]models.Delivery, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var pending []models.Delivery
+	for _, d := range s.deliveries {
+		if d.Status == models.DeliveryFailed {
+			pending = append(pending, d)
+		}
+	}
+	return pending, nil
+}
+
+func (s *fakeStore) waitForUpdate(t *testing.T) models.Delivery {
+	t.Helper()
+	select {
+	case d := <-s.updates:
+		return d
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for delivery update")
+		return models.Delivery{}
+	}
+}
+
+func TestDispatcher_DeliversAndSigns(t *testing.T) {
+	var gotSignature, gotBody string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotSignature = r.Header.Get("X-Signature")
+		body, _ := io.ReadAll(r.Body)
+		gotBody = string(body)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	hook := models.Hook{ID: "hook-1", UserID: "user-1", URL: server.URL, Secret: "topsecret", Events: []string{EventTaskCreated}}
+	fs := newFakeStore(hook)
+	clock := newFakeClock(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	d := newDispatcher(fs, clock, 2)
+
+	if err := d.Start(context.Background()); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	defer d.Stop()
+
+	task := models.Task{ID: "task-1", UserID: "user-1", Title: "Buy milk"}
+	d.Publish(Event{Type: EventTaskCreated, UserID: "user-1", Task: task})
+
+	delivered := fs.waitForUpdate(t)
+	if delivered.Status != models.DeliverySucceeded {
+		t.Fatalf("expected delivery to succeed, got status %s", delivered.Status)
+	}
+	if gotSignature != "sha256="+sign("topsecret", []byte(gotBody)) {
+		t.Fatalf("signature %q did not match expected HMAC of body", gotSignature)
+	}
+}
+
+func TestDispatcher_RetriesOnFailureThenSucceeds(t *testing.T) {
+	originalBackoff := backoffSchedule
+	backoffSchedule = []time.Duration{10 * time.Millisecond}
+	defer func() { backoffSchedule = originalBackoff }()
+
+	var attempts int32
+	var mu sync.Mutex
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		attempts++
+		n := attempts
+		mu.Unlock()
+		if n == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	hook := models.Hook{ID: "hook-1", UserID: "user-1", URL: server.URL, Secret: "s3cret", Events: []string{EventTaskCreated}}
+	fs := newFakeStore(hook)
+	clock := newFakeClock(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	d := newDispatcher(fs, clock, 1)
+
+	if err := d.Start(context.Background()); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	defer d.Stop()
+
+	d.Publish(Event{Type: EventTaskCreated, UserID: "user-1", Task: models.Task{ID: "task-1", UserID: "user-1"}})
+
+	first := fs.waitForUpdate(t)
+	if first.Status != models.DeliveryFailed {
+		t.Fatalf("expected first attempt to fail, got %s", first.Status)
+	}
+
+	second := fs.waitForUpdate(t)
+	if second.Status != models.DeliverySucceeded {
+		t.Fatalf("expected retry to succeed, got %s", second.Status)
+	}
+}
+
+func TestDispatcher_StopCancelsPendingRetryInsteadOfWaitingOutBackoff(t *testing.T) {
+	originalBackoff := backoffSchedule
+	backoffSchedule = []time.Duration{time.Hour}
+	defer func() { backoffSchedule = originalBackoff }()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	hook := models.Hook{ID: "hook-1", UserID: "user-1", URL: server.URL, Secret: "s", Events: []string{EventTaskCreated}}
+	fs := newFakeStore(hook)
+	clock := newFakeClock(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	d := newDispatcher(fs, clock, 1)
+
+	if err := d.Start(context.Background()); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+
+	d.Publish(Event{Type: EventTaskCreated, UserID: "user-1", Task: models.Task{ID: "task-1", UserID: "user-1"}})
+
+	failed := fs.waitForUpdate(t)
+	if failed.Status != models.DeliveryFailed {
+		t.Fatalf("expected first attempt to fail, got %s", failed.Status)
+	}
+
+	stopped := make(chan struct{})
+	go func() {
+		d.Stop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Fatal("Stop did not return promptly; it waited out the pending retry's hour-long backoff instead of cancelling it")
+	}
+}
+
+func TestDispatcher_RegisterAndListDeliveries(t *testing.T) {
+	fs := newFakeStore()
+	d := newDispatcher(fs, newFakeClock(time.Now()), 1)
+
+	hook, err := d.RegisterHook(context.Background(), "user-1", models.HookRequest{
+		URL: "https://example.com/hook", Secret: "s", Events: []string{EventTaskCreated},
+	})
+	if err != nil {
+		t.Fatalf("RegisterHook: %v", err)
+	}
+	if hook.ID == "" {
+		t.Fatal("expected a generated hook ID")
+	}
+
+	fs.deliveries[uuid.New().String()] = models.Delivery{ID: "d-1", HookID: hook.ID, Status: models.DeliverySucceeded}
+
+	deliveries, err := d.ListDeliveries(context.Background(), hook.ID, "user-1")
+	if err != nil {
+		t.Fatalf("ListDeliveries: %v", err)
+	}
+	if len(deliveries) != 1 {
+		t.Fatalf("expected 1 delivery, got %d", len(deliveries))
+	}
+
+	if _, err := d.ListDeliveries(context.Background(), hook.ID, "someone-else"); err == nil {
+		t.Fatal("expected error listing deliveries for a hook owned by another user")
+	}
+}
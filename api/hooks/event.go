@@ -0,0 +1,19 @@
+package hooks
+
+import "github.com/k-satosi/todo-app-webui/api/models"
+
+// Task lifecycle events a Hook can subscribe to.
+const (
+	EventTaskCreated   = "task.created"
+	EventTaskUpdated   = "task.updated"
+	EventTaskCompleted = "task.completed"
+	EventTaskDeleted   = "task.deleted"
+)
+
+// Event is published by TaskHandler after a successful mutation and fans out
+// to every Hook the owning user registered for Type.
+type Event struct {
+	Type   string
+	UserID string
+	Task   models.Task
+}
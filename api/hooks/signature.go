@@ -0,0 +1,15 @@
+package hooks
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// sign returns the hex-encoded HMAC-SHA256 of payload under secret, for use
+// as the value of the X-Signature delivery header (prefixed "sha256=").
+func sign(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,197 @@
+package hooks
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"github.com/k-satosi/todo-app-webui/api/database"
+	"github.com/k-satosi/todo-app-webui/api/models"
+)
+
+// errNotFound mirrors sql.ErrNoRows so fakeStore in tests and sqlStore in
+// production signal "not found" the same way to callers.
+var errNotFound = sql.ErrNoRows
+
+// store is the persistence seam the Dispatcher depends on, so tests can swap
+// in an in-memory fake instead of a real database.
+type store interface {
+	insertHook(ctx context.Context, hook models.Hook) error
+	hooksForEvent(ctx context.Context, userID, eventType string) ([]models.Hook, error)
+	getHook(ctx context.Context, id, userID string) (models.Hook, error)
+	getHookByID(ctx context.Context, id string) (models.Hook, error)
+	insertDelivery(ctx context.Context, delivery models.Delivery) error
+	updateDelivery(ctx context.Context, delivery models.Delivery) error
+	listDeliveries(ctx context.Context, hookID string) ([]models.Delivery, error)
+	getDelivery(ctx context.Context, id string) (models.Delivery, error)
+	pendingDeliveries(ctx context.Context) ([]models.Delivery, error)
+}
+
+type sqlStore struct {
+	db      *sql.DB
+	dialect database.Dialect
+}
+
+func joinEvents(events []string) string { return strings.Join(events, ",") }
+func splitEvents(events string) []string {
+	if events == "" {
+		return nil
+	}
+	return strings.Split(events, ",")
+}
+
+func (s *sqlStore) insertHook(ctx context.Context, hook models.Hook) error {
+	_, err := s.db.ExecContext(ctx, s.dialect.Rebind(`
+		INSERT INTO hooks (id, user_id, url, secret, events, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`), hook.ID, hook.UserID, hook.URL, hook.Secret, joinEvents(hook.Events), hook.CreatedAt, hook.UpdatedAt)
+	return err
+}
+
+func (s *sqlStore) hooksForEvent(ctx context.Context, userID, eventType string) ([]models.Hook, error) {
+	rows, err := s.db.QueryContext(ctx, s.dialect.Rebind(`
+		SELECT id, user_id, url, secret, events, created_at, updated_at
+		FROM hooks
+		WHERE user_id = ?
+	`), userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var matched []models.Hook
+	for rows.Next() {
+		hook, events, err := scanHook(rows)
+		if err != nil {
+			return nil, err
+		}
+		hook.Events = splitEvents(events)
+		for _, e := range hook.Events {
+			if e == eventType {
+				matched = append(matched, hook)
+				break
+			}
+		}
+	}
+	return matched, rows.Err()
+}
+
+func (s *sqlStore) getHook(ctx context.Context, id, userID string) (models.Hook, error) {
+	var hook models.Hook
+	var events string
+	err := s.db.QueryRowContext(ctx, s.dialect.Rebind(`
+		SELECT id, user_id, url, secret, events, created_at, updated_at
+		FROM hooks
+		WHERE id = ? AND user_id = ?
+	`), id, userID).Scan(&hook.ID, &hook.UserID, &hook.URL, &hook.Secret, &events, &hook.CreatedAt, &hook.UpdatedAt)
+	if err != nil {
+		return models.Hook{}, err
+	}
+	hook.Events = splitEvents(events)
+	return hook, nil
+}
+
+func (s *sqlStore) getHookByID(ctx context.Context, id string) (models.Hook, error) {
+	var hook models.Hook
+	var events string
+	err := s.db.QueryRowContext(ctx, s.dialect.Rebind(`
+		SELECT id, user_id, url, secret, events, created_at, updated_at
+		FROM hooks
+		WHERE id = ?
+	`), id).Scan(&hook.ID, &hook.UserID, &hook.URL, &hook.Secret, &events, &hook.CreatedAt, &hook.UpdatedAt)
+	if err != nil {
+		return models.Hook{}, err
+	}
+	hook.Events = splitEvents(events)
+	return hook, nil
+}
+
+func (s *sqlStore) insertDelivery(ctx context.Context, delivery models.Delivery) error {
+	_, err := s.db.ExecContext(ctx, s.dialect.Rebind(`
+		INSERT INTO deliveries (id, hook_id, event, payload, status, attempt, status_code, error, next_retry_at, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`), delivery.ID, delivery.HookID, delivery.Event, delivery.Payload, delivery.Status, delivery.Attempt,
+		delivery.StatusCode, delivery.Error, delivery.NextRetryAt, delivery.CreatedAt, delivery.UpdatedAt)
+	return err
+}
+
+func (s *sqlStore) updateDelivery(ctx context.Context, delivery models.Delivery) error {
+	_, err := s.db.ExecContext(ctx, s.dialect.Rebind(`
+		UPDATE deliveries
+		SET status = ?, attempt = ?, status_code = ?, error = ?, next_retry_at = ?, updated_at = ?
+		WHERE id = ?
+	`), delivery.Status, delivery.Attempt, delivery.StatusCode, delivery.Error, delivery.NextRetryAt, delivery.UpdatedAt, delivery.ID)
+	return err
+}
+
+func (s *sqlStore) listDeliveries(ctx context.Context, hookID string) ([]models.Delivery, error) {
+	rows, err := s.db.QueryContext(ctx, s.dialect.Rebind(`
+		SELECT id, hook_id, event, payload, status, attempt, status_code, error, next_retry_at, created_at, updated_at
+		FROM deliveries
+		WHERE hook_id = ?
+		ORDER BY created_at DESC
+	`), hookID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var deliveries []models.Delivery
+	for rows.Next() {
+		delivery, err := scanDelivery(rows)
+		if err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, delivery)
+	}
+	return deliveries, rows.Err()
+}
+
+func (s *sqlStore) getDelivery(ctx context.Context, id string) (models.Delivery, error) {
+	row := s.db.QueryRowContext(ctx, s.dialect.Rebind(`
+		SELECT id, hook_id, event, payload, status, attempt, status_code, error, next_retry_at, created_at, updated_at
+		FROM deliveries
+		WHERE id = ?
+	`), id)
+	return scanDelivery(row)
+}
+
+func (s *sqlStore) pendingDeliveries(ctx context.Context) ([]models.Delivery, error) {
+	rows, err := s.db.QueryContext(ctx, s.dialect.Rebind(`
+		SELECT id, hook_id, event, payload, status, attempt, status_code, error, next_retry_at, created_at, updated_at
+		FROM deliveries
+		WHERE status = ?
+	`), models.DeliveryFailed)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var deliveries []models.Delivery
+	for rows.Next() {
+		delivery, err := scanDelivery(rows)
+		if err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, delivery)
+	}
+	return deliveries, rows.Err()
+}
+
+// rowScanner is satisfied by both *sql.Row and *sql.Rows.
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanDelivery(row rowScanner) (models.Delivery, error) {
+	var d models.Delivery
+	err := row.Scan(&d.ID, &d.HookID, &d.Event, &d.Payload, &d.Status, &d.Attempt, &d.StatusCode, &d.Error, &d.NextRetryAt, &d.CreatedAt, &d.UpdatedAt)
+	return d, err
+}
+
+func scanHook(row rowScanner) (models.Hook, string, error) {
+	var h models.Hook
+	var events string
+	err := row.Scan(&h.ID, &h.UserID, &h.URL, &h.Secret, &events, &h.CreatedAt, &h.UpdatedAt)
+	return h, events, err
+}
@@ -1,23 +1,39 @@
 package main
 
 import (
+	"context"
 	"log"
 	"net/http"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 	"github.com/k-satosi/todo-app-webui/api/database"
 	"github.com/k-satosi/todo-app-webui/api/handlers"
+	"github.com/k-satosi/todo-app-webui/api/hooks"
+	"github.com/k-satosi/todo-app-webui/api/middleware"
+	"github.com/k-satosi/todo-app-webui/api/repository"
+	"github.com/k-satosi/todo-app-webui/api/scheduler"
 )
 
+// shutdownTimeout bounds how long graceful shutdown waits for in-flight
+// requests to finish before the server is torn down anyway.
+const shutdownTimeout = 10 * time.Second
+
+// hookDeliveryWorkers is the number of goroutines concurrently delivering
+// webhook payloads.
+const hookDeliveryWorkers = 4
+
 func main() {
 	gin.SetMode(gin.ReleaseMode)
 	if os.Getenv("GIN_MODE") == "debug" {
 		gin.SetMode(gin.DebugMode)
 	}
 
-	db, err := database.InitDB()
+	db, dialect, err := database.InitDB()
 	if err != nil {
 		log.Fatalf("Failed to connect to database: %v", err)
 	}
@@ -33,17 +49,57 @@ func main() {
 		AllowCredentials: true,
 	}))
 
-	taskHandler := handlers.NewTaskHandler(db)
-	
+	schedulerMgr := scheduler.NewManager(db, dialect, nil)
+	if err := schedulerMgr.Start(context.Background()); err != nil {
+		log.Fatalf("Failed to start scheduler: %v", err)
+	}
+	defer schedulerMgr.Stop()
+
+	hookDispatcher := hooks.NewDispatcher(db, dialect, hookDeliveryWorkers)
+	if err := hookDispatcher.Start(context.Background()); err != nil {
+		log.Fatalf("Failed to start hook dispatcher: %v", err)
+	}
+	defer hookDispatcher.Stop()
+
+	taskRepo := repository.NewRepository(db, dialect)
+	taskHandler := handlers.NewTaskHandler(taskRepo, schedulerMgr, hookDispatcher)
+	authHandler := handlers.NewAuthHandler(db, dialect)
+	executionHandler := handlers.NewExecutionHandler(db, dialect, schedulerMgr)
+	hookHandler := handlers.NewHookHandler(hookDispatcher)
+
 	v1 := r.Group("/api/v1")
 	{
+		auth := v1.Group("/auth")
+		{
+			auth.POST("/register", authHandler.Register)
+			auth.POST("/login", authHandler.Login)
+			auth.POST("/refresh", authHandler.Refresh)
+			auth.POST("/logout", authHandler.Logout)
+		}
+
 		tasks := v1.Group("/tasks")
+		tasks.Use(middleware.RequireAuth())
 		{
 			tasks.GET("", taskHandler.GetTasks)
 			tasks.POST("", taskHandler.CreateTask)
 			tasks.GET("/:id", taskHandler.GetTask)
 			tasks.PUT("/:id", taskHandler.UpdateTask)
 			tasks.DELETE("/:id", taskHandler.DeleteTask)
+
+			executions := tasks.Group("/:id/executions")
+			{
+				executions.GET("", executionHandler.ListExecutions)
+				executions.POST("/:executionId/complete", executionHandler.CompleteExecution)
+				executions.POST("/:executionId/skip", executionHandler.SkipExecution)
+			}
+		}
+
+		hooksGroup := v1.Group("/hooks")
+		hooksGroup.Use(middleware.RequireAuth())
+		{
+			hooksGroup.POST("", hookHandler.CreateHook)
+			hooksGroup.GET("/:id/deliveries", hookHandler.ListDeliveries)
+			hooksGroup.POST("/:id/deliveries/:deliveryId/retry", hookHandler.RetryDelivery)
 		}
 	}
 
@@ -51,9 +107,27 @@ func main() {
 	if port == "" {
 		port = "8080"
 	}
-	
-	log.Printf("Server starting on port %s", port)
-	if err := r.Run(":" + port); err != nil {
-		log.Fatalf("Failed to start server: %v", err)
+
+	srv := &http.Server{
+		Addr:    ":" + port,
+		Handler: r,
+	}
+
+	go func() {
+		log.Printf("Server starting on port %s", port)
+		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Fatalf("Failed to start server: %v", err)
+		}
+	}()
+
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	<-quit
+
+	log.Println("Shutting down server...")
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := srv.Shutdown(ctx); err != nil {
+		log.Printf("Error during server shutdown: %v", err)
 	}
 }
@@ -0,0 +1,35 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/k-satosi/todo-app-webui/api/auth"
+)
+
+// UserIDKey is the gin.Context key RequireAuth stores the authenticated user's ID under.
+const UserIDKey = "userID"
+
+// RequireAuth parses the Authorization: Bearer header, validates the JWT, and
+// injects the authenticated user's ID into the request context. Requests without
+// a valid token are rejected with 401 before reaching the handler.
+func RequireAuth() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		header := c.GetHeader("Authorization")
+		parts := strings.SplitN(header, " ", 2)
+		if len(parts) != 2 || parts[0] != "Bearer" {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Missing or malformed Authorization header"})
+			return
+		}
+
+		claims, err := auth.ParseAccessToken(parts[1])
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired token"})
+			return
+		}
+
+		c.Set(UserIDKey, claims.UserID)
+		c.Next()
+	}
+}
@@ -0,0 +1,24 @@
+package models
+
+import (
+	"time"
+)
+
+type ExecutionStatus string
+
+const (
+	ExecutionPending   ExecutionStatus = "pending"
+	ExecutionRunning   ExecutionStatus = "running"
+	ExecutionCompleted ExecutionStatus = "completed"
+	ExecutionSkipped   ExecutionStatus = "skipped"
+	ExecutionFailed    ExecutionStatus = "failed"
+)
+
+// TaskExecution represents a single concrete occurrence of a recurring Task.
+type TaskExecution struct {
+	ID           string          `json:"id"`
+	TaskID       string          `json:"taskId"`
+	ScheduledFor time.Time       `json:"scheduledFor"`
+	Status       ExecutionStatus `json:"status"`
+	CompletedAt  *time.Time      `json:"completedAt,omitempty"`
+}
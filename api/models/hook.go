@@ -0,0 +1,46 @@
+package models
+
+import "time"
+
+// Hook is a user-registered outbound webhook, fired for the task lifecycle
+// events listed in Events.
+type Hook struct {
+	ID        string    `json:"id"`
+	UserID    string    `json:"userId"`
+	URL       string    `json:"url"`
+	Secret    string    `json:"-"`
+	Events    []string  `json:"events"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// HookRequest is the payload for registering a Hook.
+type HookRequest struct {
+	URL    string   `json:"url" binding:"required,url"`
+	Secret string   `json:"secret" binding:"required"`
+	Events []string `json:"events" binding:"required,min=1"`
+}
+
+type DeliveryStatus string
+
+const (
+	DeliveryPending   DeliveryStatus = "pending"
+	DeliverySucceeded DeliveryStatus = "succeeded"
+	DeliveryFailed    DeliveryStatus = "failed"
+	DeliveryExhausted DeliveryStatus = "exhausted"
+)
+
+// Delivery records one attempt (or retry) to deliver an Event to a Hook.
+type Delivery struct {
+	ID          string         `json:"id"`
+	HookID      string         `json:"hookId"`
+	Event       string         `json:"event"`
+	Payload     string         `json:"payload"`
+	Status      DeliveryStatus `json:"status"`
+	Attempt     int            `json:"attempt"`
+	StatusCode  int            `json:"statusCode,omitempty"`
+	Error       string         `json:"error,omitempty"`
+	NextRetryAt *time.Time     `json:"nextRetryAt,omitempty"`
+	CreatedAt   time.Time      `json:"createdAt"`
+	UpdatedAt   time.Time      `json:"updatedAt"`
+}
@@ -0,0 +1,213 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/k-satosi/todo-app-webui/api/database"
+	"github.com/k-satosi/todo-app-webui/api/models"
+)
+
+// ErrNotFound is returned by Get when no task matches. Update and Delete
+// report a miss through their bool result instead.
+var ErrNotFound = errors.New("task not found")
+
+// sortableColumns allow-lists the columns List accepts in a filter's Sort
+// field, to keep it from being used to inject arbitrary SQL.
+var sortableColumns = map[string]string{
+	"title":      "title",
+	"due_date":   "due_date",
+	"created_at": "created_at",
+	"updated_at": "updated_at",
+}
+
+// sqlRepo is the database/sql-backed TaskRepository implementation shared by
+// every supported driver. dialect selects the driver-specific SQL (bind
+// parameter syntax today) it emits.
+type sqlRepo struct {
+	db      *sql.DB
+	dialect database.Dialect
+}
+
+// NewRepository returns a TaskRepository backed by db, emitting SQL suited
+// to dialect.
+func NewRepository(db *sql.DB, dialect database.Dialect) TaskRepository {
+	return &sqlRepo{db: db, dialect: dialect}
+}
+
+// placeholders generates a dialect's bind-parameter syntax ("?" or "$1",
+// "$2", ...) for a query built up across several append calls.
+type placeholders struct {
+	dialect database.Dialect
+	n       int
+}
+
+func (p *placeholders) next() string {
+	p.n++
+	return p.dialect.Placeholder(p.n)
+}
+
+func whereClause(filter TaskFilter, ph *placeholders) (string, []interface{}) {
+	clause := fmt.Sprintf("WHERE user_id = %s", ph.next())
+	args := []interface{}{filter.UserID}
+
+	switch filter.Status {
+	case "completed":
+		clause += " AND completed = TRUE"
+	case "pending":
+		clause += " AND completed = FALSE"
+	case "overdue":
+		clause += fmt.Sprintf(" AND completed = FALSE AND due_date < %s", ph.next())
+		args = append(args, time.Now())
+	}
+
+	if filter.Query != "" {
+		clause += fmt.Sprintf(" AND title LIKE %s", ph.next())
+		args = append(args, "%"+filter.Query+"%")
+	}
+	if filter.DueBefore != nil {
+		clause += fmt.Sprintf(" AND due_date < %s", ph.next())
+		args = append(args, *filter.DueBefore)
+	}
+	if filter.DueAfter != nil {
+		clause += fmt.Sprintf(" AND due_date > %s", ph.next())
+		args = append(args, *filter.DueAfter)
+	}
+
+	return clause, args
+}
+
+func orderByClause(sort []string) string {
+	if len(sort) == 0 {
+		return "due_date ASC"
+	}
+	clauses := make([]string, 0, len(sort))
+	for _, field := range sort {
+		direction := "ASC"
+		column := field
+		if strings.HasPrefix(field, "-") {
+			direction = "DESC"
+			column = field[1:]
+		}
+		if mapped, ok := sortableColumns[column]; ok {
+			clauses = append(clauses, mapped+" "+direction)
+		}
+	}
+	return strings.Join(clauses, ", ")
+}
+
+func (r *sqlRepo) List(ctx context.Context, filter TaskFilter) ([]models.Task, error) {
+	ph := &placeholders{dialect: r.dialect}
+	where, args := whereClause(filter, ph)
+	query := fmt.Sprintf(`
+		SELECT id, user_id, title, due_date, completed, recurrence_rule, created_at, updated_at
+		FROM tasks
+		%s
+		ORDER BY %s
+		LIMIT %s OFFSET %s
+	`, where, orderByClause(filter.Sort), ph.next(), ph.next())
+	args = append(args, filter.Limit, filter.Offset)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tasks []models.Task
+	for rows.Next() {
+		var task models.Task
+		var createdAt, updatedAt time.Time
+		if err := rows.Scan(&task.ID, &task.UserID, &task.Title, &task.DueDate, &task.Completed, &task.RecurrenceRule, &createdAt, &updatedAt); err != nil {
+			return nil, err
+		}
+		task.CreatedAt = createdAt
+		task.UpdatedAt = updatedAt
+		tasks = append(tasks, task)
+	}
+	return tasks, rows.Err()
+}
+
+func (r *sqlRepo) Count(ctx context.Context, filter TaskFilter) (int, error) {
+	ph := &placeholders{dialect: r.dialect}
+	where, args := whereClause(filter, ph)
+	query := fmt.Sprintf("SELECT COUNT(*) FROM tasks %s", where)
+
+	var total int
+	err := r.db.QueryRowContext(ctx, query, args...).Scan(&total)
+	return total, err
+}
+
+func (r *sqlRepo) Get(ctx context.Context, id, userID string) (models.Task, error) {
+	ph := &placeholders{dialect: r.dialect}
+	var task models.Task
+	var createdAt, updatedAt time.Time
+
+	query := fmt.Sprintf(`
+		SELECT id, user_id, title, due_date, completed, recurrence_rule, created_at, updated_at
+		FROM tasks
+		WHERE id = %s AND user_id = %s
+	`, ph.next(), ph.next())
+
+	err := r.db.QueryRowContext(ctx, query, id, userID).Scan(&task.ID, &task.UserID, &task.Title, &task.DueDate, &task.Completed, &task.RecurrenceRule, &createdAt, &updatedAt)
+
+	if err == sql.ErrNoRows {
+		return models.Task{}, ErrNotFound
+	} else if err != nil {
+		return models.Task{}, err
+	}
+
+	task.CreatedAt = createdAt
+	task.UpdatedAt = updatedAt
+	return task, nil
+}
+
+func (r *sqlRepo) Create(ctx context.Context, task models.Task) error {
+	ph := &placeholders{dialect: r.dialect}
+	query := fmt.Sprintf(`
+		INSERT INTO tasks (id, user_id, title, due_date, completed, recurrence_rule, created_at, updated_at)
+		VALUES (%s, %s, %s, %s, %s, %s, %s, %s)
+	`, ph.next(), ph.next(), ph.next(), ph.next(), ph.next(), ph.next(), ph.next(), ph.next())
+	_, err := r.db.ExecContext(ctx, query, task.ID, task.UserID, task.Title, task.DueDate, task.Completed, task.RecurrenceRule, task.CreatedAt, task.UpdatedAt)
+	return err
+}
+
+func (r *sqlRepo) Update(ctx context.Context, task models.Task) (bool, error) {
+	ph := &placeholders{dialect: r.dialect}
+	query := fmt.Sprintf(`
+		UPDATE tasks
+		SET title = %s, due_date = %s, completed = %s, recurrence_rule = %s, updated_at = %s
+		WHERE id = %s AND user_id = %s
+	`, ph.next(), ph.next(), ph.next(), ph.next(), ph.next(), ph.next(), ph.next())
+
+	result, err := r.db.ExecContext(ctx, query, task.Title, task.DueDate, task.Completed, task.RecurrenceRule, task.UpdatedAt, task.ID, task.UserID)
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return rowsAffected > 0, nil
+}
+
+func (r *sqlRepo) Delete(ctx context.Context, id, userID string) (bool, error) {
+	ph := &placeholders{dialect: r.dialect}
+	query := fmt.Sprintf("DELETE FROM tasks WHERE id = %s AND user_id = %s", ph.next(), ph.next())
+
+	result, err := r.db.ExecContext(ctx, query, id, userID)
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return rowsAffected > 0, nil
+}
@@ -0,0 +1,138 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/k-satosi/todo-app-webui/api/database"
+	"github.com/k-satosi/todo-app-webui/api/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSQLRepo_Get(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	now := time.Now()
+	rows := sqlmock.NewRows([]string{"id", "user_id", "title", "due_date", "completed", "recurrence_rule", "created_at", "updated_at"}).
+		AddRow("task-1", "user-1", "Buy milk", now, false, "", now, now)
+
+	mock.ExpectQuery("SELECT id, user_id, title, due_date, completed, recurrence_rule, created_at, updated_at").
+		WithArgs("task-1", "user-1").
+		WillReturnRows(rows)
+
+	repo := NewRepository(db, database.Dialect{Driver: database.DriverMySQL})
+	task, err := repo.Get(context.Background(), "task-1", "user-1")
+	require.NoError(t, err)
+	assert.Equal(t, "Buy milk", task.Title)
+	assert.Equal(t, "user-1", task.UserID)
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSQLRepo_GetNotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT id, user_id, title, due_date, completed, recurrence_rule, created_at, updated_at").
+		WithArgs("missing", "user-1").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "title", "due_date", "completed", "recurrence_rule", "created_at", "updated_at"}))
+
+	repo := NewRepository(db, database.Dialect{Driver: database.DriverMySQL})
+	_, err = repo.Get(context.Background(), "missing", "user-1")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSQLRepo_Create(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	now := time.Now()
+	task := models.Task{ID: "task-1", UserID: "user-1", Title: "Buy milk", DueDate: now, CreatedAt: now, UpdatedAt: now}
+
+	mock.ExpectExec("INSERT INTO tasks").
+		WithArgs(task.ID, task.UserID, task.Title, task.DueDate, task.Completed, task.RecurrenceRule, task.CreatedAt, task.UpdatedAt).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	repo := NewRepository(db, database.Dialect{Driver: database.DriverMySQL})
+	require.NoError(t, repo.Create(context.Background(), task))
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSQLRepo_UpdateNotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	task := models.Task{ID: "missing", UserID: "user-1", Title: "Buy milk", DueDate: time.Now(), UpdatedAt: time.Now()}
+
+	mock.ExpectExec("UPDATE tasks").
+		WithArgs(task.Title, task.DueDate, task.Completed, task.RecurrenceRule, task.UpdatedAt, task.ID, task.UserID).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	repo := NewRepository(db, database.Dialect{Driver: database.DriverMySQL})
+	found, err := repo.Update(context.Background(), task)
+	require.NoError(t, err)
+	assert.False(t, found)
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSQLRepo_Delete(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectExec("DELETE FROM tasks").
+		WithArgs("task-1", "user-1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	repo := NewRepository(db, database.Dialect{Driver: database.DriverMySQL})
+	found, err := repo.Delete(context.Background(), "task-1", "user-1")
+	require.NoError(t, err)
+	assert.True(t, found)
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSQLRepo_Count(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT COUNT\\(\\*\\) FROM tasks").
+		WithArgs("user-1").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+
+	repo := NewRepository(db, database.Dialect{Driver: database.DriverMySQL})
+	total, err := repo.Count(context.Background(), TaskFilter{UserID: "user-1"})
+	require.NoError(t, err)
+	assert.Equal(t, 3, total)
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSQLRepo_Postgres_UsesNumberedPlaceholders(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectQuery(`SELECT COUNT\(\*\) FROM tasks WHERE user_id = \$1`).
+		WithArgs("user-1").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+	repo := NewRepository(db, database.Dialect{Driver: database.DriverPostgres})
+	total, err := repo.Count(context.Background(), TaskFilter{UserID: "user-1"})
+	require.NoError(t, err)
+	assert.Equal(t, 1, total)
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
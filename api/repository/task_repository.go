@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/k-satosi/todo-app-webui/api/models"
+)
+
+// TaskFilter describes the scoping, filtering, sorting, and pagination a
+// TaskRepository.List/Count call should apply.
+type TaskFilter struct {
+	UserID    string
+	Status    string
+	Query     string
+	DueBefore *time.Time
+	DueAfter  *time.Time
+	Sort      []string
+	Limit     int
+	Offset    int
+}
+
+// TaskRepository decouples HTTP handlers from SQL, so handlers can be tested
+// against a hand-written in-memory implementation and backends can be swapped
+// without touching handler code.
+type TaskRepository interface {
+	List(ctx context.Context, filter TaskFilter) ([]models.Task, error)
+	Count(ctx context.Context, filter TaskFilter) (int, error)
+	Get(ctx context.Context, id, userID string) (models.Task, error)
+	Create(ctx context.Context, task models.Task) error
+	Update(ctx context.Context, task models.Task) (bool, error)
+	Delete(ctx context.Context, id, userID string) (bool, error)
+}
@@ -0,0 +1,14 @@
+package scheduler
+
+import "time"
+
+// Clock abstracts time so recurrence can be driven deterministically in tests.
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time                     { return time.Now() }
+func (realClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
@@ -0,0 +1,67 @@
+package scheduler
+
+import (
+	"sync"
+	"time"
+)
+
+// fakeClock is a manually-advanced Clock used to exercise recurrence
+// deterministically, without sleeping in real time.
+type fakeClock struct {
+	mu      sync.Mutex
+	now     time.Time
+	waiters []*fakeWaiter
+}
+
+type fakeWaiter struct {
+	fireAt time.Time
+	ch     chan time.Time
+}
+
+func newFakeClock(start time.Time) *fakeClock {
+	return &fakeClock{now: start}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ch := make(chan time.Time, 1)
+	fireAt := c.now.Add(d)
+	if !fireAt.After(c.now) {
+		ch <- fireAt
+		return ch
+	}
+	c.waiters = append(c.waiters, &fakeWaiter{fireAt: fireAt, ch: ch})
+	return ch
+}
+
+// Advance moves the clock forward by d, firing any pending timers whose
+// fire time has now been reached.
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	c.now = c.now.Add(d)
+	now := c.now
+
+	var remaining []*fakeWaiter
+	var fired []*fakeWaiter
+	for _, w := range c.waiters {
+		if !w.fireAt.After(now) {
+			fired = append(fired, w)
+		} else {
+			remaining = append(remaining, w)
+		}
+	}
+	c.waiters = remaining
+	c.mu.Unlock()
+
+	for _, w := range fired {
+		w.ch <- w.fireAt
+	}
+}
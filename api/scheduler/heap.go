@@ -0,0 +1,41 @@
+package scheduler
+
+import "time"
+
+// occurrence is a single pending (task, fire-time) pair waiting in the heap.
+type occurrence struct {
+	taskID string
+	userID string
+	fireAt time.Time
+	rule   *RRule
+	fired  int
+	index  int
+}
+
+// occurrenceHeap is a min-heap of occurrences ordered by fireAt, implementing
+// container/heap.Interface.
+type occurrenceHeap []*occurrence
+
+func (h occurrenceHeap) Len() int           { return len(h) }
+func (h occurrenceHeap) Less(i, j int) bool { return h[i].fireAt.Before(h[j].fireAt) }
+func (h occurrenceHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *occurrenceHeap) Push(x interface{}) {
+	item := x.(*occurrence)
+	item.index = len(*h)
+	*h = append(*h, item)
+}
+
+func (h *occurrenceHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	item := old[n-1]
+	old[n-1] = nil
+	item.index = -1
+	*h = old[:n-1]
+	return item
+}
@@ -0,0 +1,271 @@
+package scheduler
+
+import (
+	"container/heap"
+	"context"
+	"database/sql"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/k-satosi/todo-app-webui/api/database"
+	"github.com/k-satosi/todo-app-webui/api/models"
+)
+
+// StatusHook is notified whenever an execution transitions status, whether
+// triggered by the scheduler firing, a REST call, or an external webhook.
+type StatusHook interface {
+	HandleExecutionStatusChange(execution models.TaskExecution)
+}
+
+// Manager turns recurring tasks into concrete TaskExecution rows. It keeps a
+// min-heap of pending occurrences keyed by fire time and sleeps via a single
+// timer until the earliest one is due.
+type Manager struct {
+	store store
+	clock Clock
+
+	mu          sync.Mutex
+	heap        occurrenceHeap
+	occurrences map[string]*occurrence // taskID -> its single pending heap entry
+
+	hooksMu sync.Mutex
+	hooks   []StatusHook
+
+	wakeCh chan struct{}
+	stopCh chan struct{}
+	doneCh chan struct{}
+}
+
+// NewManager constructs a Manager backed by db. Pass nil clock to use the
+// real wall clock; tests supply a fake clock to drive recurrence deterministically.
+func NewManager(db *sql.DB, dialect database.Dialect, clock Clock) *Manager {
+	return newManager(&sqlStore{db: db, dialect: dialect}, clock)
+}
+
+func newManager(s store, clock Clock) *Manager {
+	if clock == nil {
+		clock = realClock{}
+	}
+	return &Manager{
+		store:       s,
+		clock:       clock,
+		occurrences: make(map[string]*occurrence),
+		wakeCh:      make(chan struct{}, 1),
+		stopCh:      make(chan struct{}),
+		doneCh:      make(chan struct{}),
+	}
+}
+
+// RegisterHook subscribes h to future execution status changes.
+func (m *Manager) RegisterHook(h StatusHook) {
+	m.hooksMu.Lock()
+	defer m.hooksMu.Unlock()
+	m.hooks = append(m.hooks, h)
+}
+
+// Start loads every recurring task from the database, seeds the heap with
+// its next occurrence, and begins the run loop in a background goroutine.
+func (m *Manager) Start(ctx context.Context) error {
+	tasks, err := m.store.loadRecurringTasks(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, t := range tasks {
+		rule, err := ParseRRule(t.rule)
+		if err != nil {
+			log.Printf("Skipping task %s with invalid recurrence rule: %v", t.taskID, err)
+			continue
+		}
+		m.scheduleNext(t.taskID, t.userID, t.dueDate, rule)
+	}
+
+	go m.run()
+	return nil
+}
+
+// AddRecurringTask seeds the heap with the next occurrence for a task created
+// or updated with a recurrence rule after the manager has already started.
+func (m *Manager) AddRecurringTask(taskID, userID string, dueDate time.Time, rule *RRule) {
+	m.scheduleNext(taskID, userID, dueDate, rule)
+}
+
+// scheduleNext seeds the heap with the first occurrence strictly after
+// dueDate (the task's own due date never fires an execution itself),
+// skipping tasks whose recurrence is already past UNTIL.
+func (m *Manager) scheduleNext(taskID, userID string, dueDate time.Time, rule *RRule) {
+	fireAt, ok := rule.Next(dueDate)
+	if !ok {
+		return
+	}
+	m.schedule(&occurrence{taskID: taskID, userID: userID, fireAt: fireAt, rule: rule})
+}
+
+// schedule pushes o onto the heap, replacing any occurrence already pending
+// for the same task so editing a recurring task never leaves two occurrence
+// chains firing for it in parallel.
+func (m *Manager) schedule(o *occurrence) {
+	m.mu.Lock()
+	if existing, ok := m.occurrences[o.taskID]; ok && existing.index >= 0 {
+		heap.Remove(&m.heap, existing.index)
+	}
+	heap.Push(&m.heap, o)
+	m.occurrences[o.taskID] = o
+	m.mu.Unlock()
+
+	select {
+	case m.wakeCh <- struct{}{}:
+	default:
+	}
+}
+
+// Cancel removes any occurrence pending for taskID, e.g. because the task
+// was deleted or its recurrence rule was cleared. It is a no-op if the task
+// has no pending occurrence.
+func (m *Manager) Cancel(taskID string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	existing, ok := m.occurrences[taskID]
+	if !ok {
+		return
+	}
+	if existing.index >= 0 {
+		heap.Remove(&m.heap, existing.index)
+	}
+	delete(m.occurrences, taskID)
+}
+
+// run is the single goroutine driving the heap: it sleeps until the earliest
+// occurrence is due, fires it, then recomputes the sleep duration.
+func (m *Manager) run() {
+	defer close(m.doneCh)
+
+	for {
+		timer := m.clock.After(m.nextDelay())
+
+		select {
+		case <-m.stopCh:
+			return
+		case <-m.wakeCh:
+			continue
+		case <-timer:
+			m.fireDue()
+		}
+	}
+}
+
+// nextDelay returns how long to sleep until the earliest pending occurrence,
+// or a long idle interval if the heap is empty.
+func (m *Manager) nextDelay() time.Duration {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if len(m.heap) == 0 {
+		return 24 * time.Hour
+	}
+	delay := m.heap[0].fireAt.Sub(m.clock.Now())
+	if delay < 0 {
+		return 0
+	}
+	return delay
+}
+
+// fireDue pops and processes every occurrence whose fireAt is no later than now.
+func (m *Manager) fireDue() {
+	now := m.clock.Now()
+
+	for {
+		m.mu.Lock()
+		if len(m.heap) == 0 || m.heap[0].fireAt.After(now) {
+			m.mu.Unlock()
+			return
+		}
+		o := heap.Pop(&m.heap).(*occurrence)
+		m.mu.Unlock()
+
+		execution := models.TaskExecution{
+			ID:           uuid.New().String(),
+			TaskID:       o.taskID,
+			ScheduledFor: o.fireAt,
+			Status:       models.ExecutionPending,
+		}
+		if err := m.store.insertExecution(context.Background(), execution); err != nil {
+			log.Printf("Error inserting execution for task %s: %v", o.taskID, err)
+		} else {
+			m.notifyHooks(execution)
+		}
+
+		o.fired++
+		if o.rule.Count > 0 && o.fired >= o.rule.Count {
+			m.mu.Lock()
+			if m.occurrences[o.taskID] == o {
+				delete(m.occurrences, o.taskID)
+			}
+			m.mu.Unlock()
+			continue
+		}
+
+		next, ok := o.rule.Next(o.fireAt)
+		if !ok {
+			m.mu.Lock()
+			if m.occurrences[o.taskID] == o {
+				delete(m.occurrences, o.taskID)
+			}
+			m.mu.Unlock()
+			continue
+		}
+		o.fireAt = next
+		m.mu.Lock()
+		// Cancel or a re-registration may have dropped or replaced o while it
+		// was out of the heap; only the task's current occurrence is re-queued.
+		if m.occurrences[o.taskID] == o {
+			heap.Push(&m.heap, o)
+		}
+		m.mu.Unlock()
+	}
+}
+
+// UpdateExecutionStatus transitions an execution's status. It is the single
+// entry point used by both the REST handlers and external hooks (e.g. a
+// webhook reporting completion) so every caller goes through the same path.
+// userID scopes the transition to executions belonging to that user's own
+// tasks, the same way ListExecutions scopes its query.
+func (m *Manager) UpdateExecutionStatus(ctx context.Context, executionID, userID string, status models.ExecutionStatus) (models.TaskExecution, error) {
+	var completedAt *time.Time
+	if status == models.ExecutionCompleted {
+		now := m.clock.Now()
+		completedAt = &now
+	}
+
+	if err := m.store.updateExecutionStatus(ctx, executionID, userID, status, completedAt); err != nil {
+		return models.TaskExecution{}, err
+	}
+
+	execution, err := m.store.getExecution(ctx, executionID, userID)
+	if err != nil {
+		return models.TaskExecution{}, err
+	}
+
+	m.notifyHooks(execution)
+	return execution, nil
+}
+
+func (m *Manager) notifyHooks(execution models.TaskExecution) {
+	m.hooksMu.Lock()
+	hooks := append([]StatusHook(nil), m.hooks...)
+	m.hooksMu.Unlock()
+
+	for _, h := range hooks {
+		h.HandleExecutionStatusChange(execution)
+	}
+}
+
+// Stop signals the run loop to exit and waits for it to finish, so no
+// occurrence fires after shutdown begins.
+func (m *Manager) Stop() {
+	close(m.stopCh)
+	<-m.doneCh
+}
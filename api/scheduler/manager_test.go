@@ -0,0 +1,266 @@
+package scheduler
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/k-satosi/todo-app-webui/api/models"
+)
+
+// fakeStore is an in-memory stand-in for sqlStore, so Manager can be tested
+// without a real database.
+type fakeStore struct {
+	mu         sync.Mutex
+	recurring  []recurringTask
+	executions map[string]models.TaskExecution
+	taskOwners map[string]string // taskID -> userID, for ownership checks in tests that set it
+	inserted   chan models.TaskExecution
+}
+
+func newFakeStore(recurring ...recurringTask) *fakeStore {
+	return &fakeStore{
+		recurring:  recurring,
+		executions: make(map[string]models.TaskExecution),
+		inserted:   make(chan models.TaskExecution, 16),
+	}
+}
+
+func (s *fakeStore) loadRecurringTasks(ctx context.Context) ([]recurringTask, error) {
+	return s.recurring, nil
+}
+
+func (s *fakeStore) insertExecution(ctx context.Context, execution models.TaskExecution) error {
+	s.mu.Lock()
+	s.executions[execution.ID] = execution
+	s.mu.Unlock()
+	s.inserted <- execution
+	return nil
+}
+
+func (s *fakeStore) updateExecutionStatus(ctx context.Context, executionID, userID string, status models.ExecutionStatus, completedAt *time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	execution, ok := s.executions[executionID]
+	if !ok || !s.owns(execution.TaskID, userID) {
+		return errNotFound
+	}
+	execution.Status = status
+	execution.CompletedAt = completedAt
+	s.executions[executionID] = execution
+	return nil
+}
+
+func (s *fakeStore) getExecution(ctx context.Context, executionID, userID string) (models.TaskExecution, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	execution, ok := s.executions[executionID]
+	if !ok || !s.owns(execution.TaskID, userID) {
+		return models.TaskExecution{}, errNotFound
+	}
+	return execution, nil
+}
+
+// owns reports whether userID owns taskID. Tests that don't care about
+// ownership scoping simply leave taskOwners nil, in which case every task is
+// considered owned by every caller.
+func (s *fakeStore) owns(taskID, userID string) bool {
+	if s.taskOwners == nil {
+		return true
+	}
+	return s.taskOwners[taskID] == userID
+}
+
+func (s *fakeStore) waitForInsert(t *testing.T) models.TaskExecution {
+	t.Helper()
+	select {
+	case execution := <-s.inserted:
+		return execution
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for execution to be inserted")
+		return models.TaskExecution{}
+	}
+}
+
+func TestManager_FiresDailyRecurrenceAndReschedules(t *testing.T) {
+	start := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+	rule, err := ParseRRule("FREQ=DAILY")
+	if err != nil {
+		t.Fatalf("ParseRRule: %v", err)
+	}
+
+	clock := newFakeClock(start)
+	fs := newFakeStore()
+	mgr := newManager(fs, clock)
+	mgr.AddRecurringTask("task-1", "user-1", start, rule)
+
+	go mgr.run()
+	defer mgr.Stop()
+
+	clock.Advance(24 * time.Hour)
+	first := fs.waitForInsert(t)
+	if first.TaskID != "task-1" {
+		t.Fatalf("expected execution for task-1, got %s", first.TaskID)
+	}
+	if !first.ScheduledFor.Equal(start.Add(24 * time.Hour)) {
+		t.Fatalf("expected execution scheduled for %v, got %v", start.Add(24*time.Hour), first.ScheduledFor)
+	}
+
+	clock.Advance(24 * time.Hour)
+	second := fs.waitForInsert(t)
+	if !second.ScheduledFor.Equal(start.Add(48 * time.Hour)) {
+		t.Fatalf("expected execution scheduled for %v, got %v", start.Add(48*time.Hour), second.ScheduledFor)
+	}
+}
+
+func TestManager_StopsAfterCountExhausted(t *testing.T) {
+	start := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+	rule, err := ParseRRule("FREQ=DAILY;COUNT=1")
+	if err != nil {
+		t.Fatalf("ParseRRule: %v", err)
+	}
+
+	clock := newFakeClock(start)
+	fs := newFakeStore()
+	mgr := newManager(fs, clock)
+	mgr.AddRecurringTask("task-1", "user-1", start, rule)
+
+	go mgr.run()
+	defer mgr.Stop()
+
+	clock.Advance(24 * time.Hour)
+	fs.waitForInsert(t)
+
+	mgr.mu.Lock()
+	remaining := len(mgr.heap)
+	mgr.mu.Unlock()
+	if remaining != 0 {
+		t.Fatalf("expected no further occurrences after COUNT exhausted, got %d pending", remaining)
+	}
+}
+
+func TestManager_UpdateExecutionStatusNotifiesHooks(t *testing.T) {
+	clock := newFakeClock(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	fs := newFakeStore()
+	mgr := newManager(fs, clock)
+
+	execution := models.TaskExecution{ID: "exec-1", TaskID: "task-1", Status: models.ExecutionPending}
+	fs.executions[execution.ID] = execution
+
+	notified := make(chan models.TaskExecution, 1)
+	mgr.RegisterHook(hookFunc(func(e models.TaskExecution) { notified <- e }))
+
+	updated, err := mgr.UpdateExecutionStatus(context.Background(), "exec-1", "user-1", models.ExecutionCompleted)
+	if err != nil {
+		t.Fatalf("UpdateExecutionStatus: %v", err)
+	}
+	if updated.Status != models.ExecutionCompleted {
+		t.Fatalf("expected status %s, got %s", models.ExecutionCompleted, updated.Status)
+	}
+
+	select {
+	case e := <-notified:
+		if e.ID != "exec-1" {
+			t.Fatalf("expected hook for exec-1, got %s", e.ID)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for hook notification")
+	}
+}
+
+func TestManager_UpdateExecutionStatusRejectsOtherUsersTask(t *testing.T) {
+	clock := newFakeClock(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	fs := newFakeStore()
+	fs.taskOwners = map[string]string{"task-1": "user-1"}
+	mgr := newManager(fs, clock)
+
+	fs.executions["exec-1"] = models.TaskExecution{ID: "exec-1", TaskID: "task-1", Status: models.ExecutionPending}
+
+	if _, err := mgr.UpdateExecutionStatus(context.Background(), "exec-1", "user-2", models.ExecutionCompleted); err != errNotFound {
+		t.Fatalf("expected errNotFound for a task owned by a different user, got %v", err)
+	}
+
+	if _, err := mgr.UpdateExecutionStatus(context.Background(), "exec-1", "user-1", models.ExecutionCompleted); err != nil {
+		t.Fatalf("UpdateExecutionStatus for the owning user: %v", err)
+	}
+}
+
+func TestManager_ReRegisteringRecurringTaskReplacesPendingOccurrence(t *testing.T) {
+	start := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+	rule, err := ParseRRule("FREQ=DAILY")
+	if err != nil {
+		t.Fatalf("ParseRRule: %v", err)
+	}
+
+	clock := newFakeClock(start)
+	fs := newFakeStore()
+	mgr := newManager(fs, clock)
+
+	mgr.AddRecurringTask("task-1", "user-1", start, rule)
+	// Editing the task (e.g. changing its title) re-registers it with the
+	// same recurrence; this must replace, not duplicate, the pending entry.
+	mgr.AddRecurringTask("task-1", "user-1", start, rule)
+
+	mgr.mu.Lock()
+	pending := len(mgr.heap)
+	mgr.mu.Unlock()
+	if pending != 1 {
+		t.Fatalf("expected exactly 1 pending occurrence for task-1 after re-registration, got %d", pending)
+	}
+
+	go mgr.run()
+	defer mgr.Stop()
+
+	clock.Advance(24 * time.Hour)
+	fs.waitForInsert(t)
+
+	select {
+	case <-fs.inserted:
+		t.Fatal("expected only one execution to fire, got a second")
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestManager_CancelRemovesPendingOccurrence(t *testing.T) {
+	start := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+	rule, err := ParseRRule("FREQ=DAILY")
+	if err != nil {
+		t.Fatalf("ParseRRule: %v", err)
+	}
+
+	clock := newFakeClock(start)
+	fs := newFakeStore()
+	mgr := newManager(fs, clock)
+
+	mgr.AddRecurringTask("task-1", "user-1", start, rule)
+	mgr.Cancel("task-1")
+
+	mgr.mu.Lock()
+	pending := len(mgr.heap)
+	mgr.mu.Unlock()
+	if pending != 0 {
+		t.Fatalf("expected no pending occurrences after Cancel, got %d", pending)
+	}
+
+	go mgr.run()
+	defer mgr.Stop()
+
+	clock.Advance(24 * time.Hour)
+
+	select {
+	case <-fs.inserted:
+		t.Fatal("expected no execution to fire for a cancelled task")
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestManager_CancelUnknownTaskIsNoop(t *testing.T) {
+	mgr := newManager(newFakeStore(), newFakeClock(time.Now()))
+	mgr.Cancel("does-not-exist")
+}
+
+// hookFunc adapts a function to the StatusHook interface for tests.
+type hookFunc func(models.TaskExecution)
+
+func (f hookFunc) HandleExecutionStatusChange(execution models.TaskExecution) { f(execution) }
@@ -0,0 +1,145 @@
+package scheduler
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Frequency string
+
+const (
+	FreqDaily   Frequency = "DAILY"
+	FreqWeekly  Frequency = "WEEKLY"
+	FreqMonthly Frequency = "MONTHLY"
+)
+
+var weekdayByAbbr = map[string]time.Weekday{
+	"SU": time.Sunday,
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+}
+
+// RRule is the subset of RFC 5545 recurrence rules this package understands:
+// FREQ, INTERVAL, BYDAY, COUNT and UNTIL. BYDAY is applied to WEEKLY rules
+// only; DAILY and MONTHLY rules parse it but ignore it.
+type RRule struct {
+	Freq     Frequency
+	Interval int
+	ByDay    []time.Weekday
+	Count    int
+	Until    time.Time
+}
+
+// ParseRRule parses an RRULE value such as "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,WE;COUNT=10".
+func ParseRRule(rule string) (*RRule, error) {
+	r := &RRule{Interval: 1}
+
+	for _, part := range strings.Split(rule, ";") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		kv := strings.SplitN(part, "=", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("invalid RRULE component: %q", part)
+		}
+		key, value := strings.ToUpper(kv[0]), kv[1]
+
+		switch key {
+		case "FREQ":
+			switch Frequency(strings.ToUpper(value)) {
+			case FreqDaily, FreqWeekly, FreqMonthly:
+				r.Freq = Frequency(strings.ToUpper(value))
+			default:
+				return nil, fmt.Errorf("unsupported FREQ: %q", value)
+			}
+		case "INTERVAL":
+			n, err := strconv.Atoi(value)
+			if err != nil || n <= 0 {
+				return nil, fmt.Errorf("invalid INTERVAL: %q", value)
+			}
+			r.Interval = n
+		case "BYDAY":
+			for _, abbr := range strings.Split(value, ",") {
+				wd, ok := weekdayByAbbr[strings.ToUpper(abbr)]
+				if !ok {
+					return nil, fmt.Errorf("invalid BYDAY: %q", abbr)
+				}
+				r.ByDay = append(r.ByDay, wd)
+			}
+		case "COUNT":
+			n, err := strconv.Atoi(value)
+			if err != nil || n <= 0 {
+				return nil, fmt.Errorf("invalid COUNT: %q", value)
+			}
+			r.Count = n
+		case "UNTIL":
+			until, err := time.Parse("20060102T150405Z", value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid UNTIL: %q", value)
+			}
+			r.Until = until
+		}
+	}
+
+	if r.Freq == "" {
+		return nil, fmt.Errorf("RRULE is missing FREQ")
+	}
+	return r, nil
+}
+
+// Next returns the first occurrence strictly after `after` that satisfies the
+// rule, or false if the rule has no further occurrences (past UNTIL).
+func (r *RRule) Next(after time.Time) (time.Time, bool) {
+	switch r.Freq {
+	case FreqDaily:
+		next := after.AddDate(0, 0, r.Interval)
+		return r.boundedBy(next)
+	case FreqWeekly:
+		if len(r.ByDay) == 0 {
+			next := after.AddDate(0, 0, 7*r.Interval)
+			return r.boundedBy(next)
+		}
+		return r.nextWeeklyByDay(after)
+	case FreqMonthly:
+		next := after.AddDate(0, r.Interval, 0)
+		return r.boundedBy(next)
+	default:
+		return time.Time{}, false
+	}
+}
+
+func (r *RRule) nextWeeklyByDay(after time.Time) (time.Time, bool) {
+	best := time.Time{}
+	bestDays := 0
+	for _, wd := range r.ByDay {
+		daysAhead := (int(wd) - int(after.Weekday()) + 7) % 7
+		if daysAhead == 0 {
+			daysAhead = 7
+		}
+		candidate := after.AddDate(0, 0, daysAhead)
+		if best.IsZero() || candidate.Before(best) {
+			best = candidate
+			bestDays = daysAhead
+		}
+	}
+	// Weeks start on Monday; moving into the following week skips INTERVAL-1
+	// further weeks so only every INTERVAL-th week is used.
+	if daysSinceMonday := (int(after.Weekday()) + 6) % 7; daysSinceMonday+bestDays >= 7 {
+		best = best.AddDate(0, 0, 7*(r.Interval-1))
+	}
+	return r.boundedBy(best)
+}
+
+func (r *RRule) boundedBy(t time.Time) (time.Time, bool) {
+	if !r.Until.IsZero() && t.After(r.Until) {
+		return time.Time{}, false
+	}
+	return t, true
+}
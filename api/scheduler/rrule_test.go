@@ -0,0 +1,89 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseRRule(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    string
+		wantErr bool
+	}{
+		{name: "daily", rule: "FREQ=DAILY"},
+		{name: "weekly with interval and byday", rule: "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,WE"},
+		{name: "monthly with count", rule: "FREQ=MONTHLY;COUNT=3"},
+		{name: "with until", rule: "FREQ=DAILY;UNTIL=20260201T000000Z"},
+		{name: "missing freq", rule: "INTERVAL=2", wantErr: true},
+		{name: "unsupported freq", rule: "FREQ=YEARLY", wantErr: true},
+		{name: "invalid interval", rule: "FREQ=DAILY;INTERVAL=0", wantErr: true},
+		{name: "invalid byday", rule: "FREQ=WEEKLY;BYDAY=XX", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseRRule(tt.rule)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseRRule(%q) error = %v, wantErr %v", tt.rule, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRRule_NextDaily(t *testing.T) {
+	rule, err := ParseRRule("FREQ=DAILY;INTERVAL=2")
+	if err != nil {
+		t.Fatalf("ParseRRule: %v", err)
+	}
+
+	after := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+	next, ok := rule.Next(after)
+	if !ok {
+		t.Fatal("expected a next occurrence")
+	}
+	want := time.Date(2026, 1, 3, 9, 0, 0, 0, time.UTC)
+	if !next.Equal(want) {
+		t.Fatalf("expected %v, got %v", want, next)
+	}
+}
+
+func TestRRule_NextWeeklyByDay(t *testing.T) {
+	rule, err := ParseRRule("FREQ=WEEKLY;BYDAY=MO,WE,FR")
+	if err != nil {
+		t.Fatalf("ParseRRule: %v", err)
+	}
+
+	// Thursday 2026-01-01 -> next occurrence should be Friday 2026-01-02.
+	after := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+	next, ok := rule.Next(after)
+	if !ok {
+		t.Fatal("expected a next occurrence")
+	}
+	want := time.Date(2026, 1, 2, 9, 0, 0, 0, time.UTC)
+	if !next.Equal(want) {
+		t.Fatalf("expected %v, got %v", want, next)
+	}
+}
+
+func TestRRule_NextRespectsUntil(t *testing.T) {
+	rule, err := ParseRRule("FREQ=DAILY;UNTIL=20260101T100000Z")
+	if err != nil {
+		t.Fatalf("ParseRRule: %v", err)
+	}
+
+	before := time.Date(2025, 12, 31, 9, 0, 0, 0, time.UTC)
+	next, ok := rule.Next(before)
+	if !ok {
+		t.Fatal("expected an occurrence before UNTIL")
+	}
+	want := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+	if !next.Equal(want) {
+		t.Fatalf("expected %v, got %v", want, next)
+	}
+
+	afterUntil := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+	if _, ok := rule.Next(afterUntil); ok {
+		t.Fatal("expected no occurrence past UNTIL")
+	}
+}
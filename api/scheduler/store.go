@@ -0,0 +1,99 @@
+package scheduler
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/k-satosi/todo-app-webui/api/database"
+	"github.com/k-satosi/todo-app-webui/api/models"
+)
+
+// errNotFound mirrors sql.ErrNoRows so fakeStore in tests and sqlStore in
+// production signal "not found" the same way to callers.
+var errNotFound = sql.ErrNoRows
+
+// recurringTask is a row loaded from the tasks table for seeding the heap on startup.
+type recurringTask struct {
+	taskID  string
+	userID  string
+	dueDate time.Time
+	rule    string
+}
+
+// store is the persistence seam the Manager depends on, so tests can swap in
+// an in-memory fake instead of a real database.
+type store interface {
+	loadRecurringTasks(ctx context.Context) ([]recurringTask, error)
+	insertExecution(ctx context.Context, execution models.TaskExecution) error
+	updateExecutionStatus(ctx context.Context, executionID, userID string, status models.ExecutionStatus, completedAt *time.Time) error
+	getExecution(ctx context.Context, executionID, userID string) (models.TaskExecution, error)
+}
+
+type sqlStore struct {
+	db      *sql.DB
+	dialect database.Dialect
+}
+
+func (s *sqlStore) loadRecurringTasks(ctx context.Context) ([]recurringTask, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT id, user_id, due_date, recurrence_rule
+		FROM tasks
+		WHERE recurrence_rule IS NOT NULL AND recurrence_rule <> ''
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tasks []recurringTask
+	for rows.Next() {
+		var t recurringTask
+		if err := rows.Scan(&t.taskID, &t.userID, &t.dueDate, &t.rule); err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, t)
+	}
+	return tasks, rows.Err()
+}
+
+func (s *sqlStore) insertExecution(ctx context.Context, execution models.TaskExecution) error {
+	_, err := s.db.ExecContext(ctx, s.dialect.Rebind(`
+		INSERT INTO task_executions (id, task_id, scheduled_for, status)
+		VALUES (?, ?, ?, ?)
+	`), execution.ID, execution.TaskID, execution.ScheduledFor, execution.Status)
+	return err
+}
+
+// updateExecutionStatus scopes the update to executions on a task owned by
+// userID via a subquery rather than an UPDATE...JOIN, which is MySQL-only
+// syntax Postgres and SQLite don't support.
+func (s *sqlStore) updateExecutionStatus(ctx context.Context, executionID, userID string, status models.ExecutionStatus, completedAt *time.Time) error {
+	result, err := s.db.ExecContext(ctx, s.dialect.Rebind(`
+		UPDATE task_executions
+		SET status = ?, completed_at = ?
+		WHERE id = ? AND task_id IN (SELECT id FROM tasks WHERE user_id = ?)
+	`), status, completedAt, executionID, userID)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return errNotFound
+	}
+	return nil
+}
+
+func (s *sqlStore) getExecution(ctx context.Context, executionID, userID string) (models.TaskExecution, error) {
+	var execution models.TaskExecution
+	err := s.db.QueryRowContext(ctx, s.dialect.Rebind(`
+		SELECT e.id, e.task_id, e.scheduled_for, e.status, e.completed_at
+		FROM task_executions e
+		JOIN tasks t ON t.id = e.task_id
+		WHERE e.id = ? AND t.user_id = ?
+	`), executionID, userID).Scan(&execution.ID, &execution.TaskID, &execution.ScheduledFor, &execution.Status, &execution.CompletedAt)
+	return execution, err
+}